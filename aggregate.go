@@ -0,0 +1,465 @@
+package memsql
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// aggregateFunctions fold every row of a group into a single cell
+var aggregateFunctions = map[string]bool{
+	"count": true,
+	"sum":   true,
+	"avg":   true,
+	"min":   true,
+	"max":   true,
+}
+
+func (exp *Expression) isAggregate() bool {
+	return exp.Kind == CallKind && aggregateFunctions[exp.Call.Name.value]
+}
+
+// containsAggregate reports whether an aggregate call appears anywhere in exp
+func (exp *Expression) containsAggregate() bool {
+	if exp == nil {
+		return false
+	}
+
+	if exp.isAggregate() {
+		return true
+	}
+
+	for _, child := range exp.children() {
+		if child.containsAggregate() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isGrouped reports whether ss folds its rows into groups, either explicitly through
+// GROUP BY and HAVING or by using an aggregate in the select list or ORDER BY
+func (ss *SelectStatement) isGrouped() bool {
+	if len(ss.GroupBy) > 0 || ss.Having != nil {
+		return true
+	}
+
+	for _, item := range ss.Item {
+		if item.Exp.containsAggregate() {
+			return true
+		}
+	}
+
+	for _, item := range ss.OrderBy {
+		if item.Exp.containsAggregate() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// groupedScope rewrites expressions that run after grouping, so they read group keys
+// and aggregate results from the grouped row instead of the source columns
+type groupedScope struct {
+	schema     []column
+	groupBy    []*Expression
+	aggregates []*Expression
+}
+
+// qualify returns exp with every column reference that resolves against cols
+// qualified by its table, so t.a and a compare equal when they name the same column
+func qualify(exp *Expression, cols []column) *Expression {
+	return mapReferences(exp, func(ref *Expression) *Expression {
+		i, err := resolveColumn(ref, cols)
+		if err != nil || cols[i].table == "" {
+			return ref
+		}
+
+		qualified := *ref
+		qualified.Table = &Token{
+			value: cols[i].table,
+			kind:  identifierKind,
+		}
+
+		return &qualified
+	})
+}
+
+func groupedColumnName(prefix string, i int) string {
+	// # can't start an identifier, so these never clash with real columns
+	return "#" + prefix + strconv.Itoa(i)
+}
+
+func groupedColumnReference(name string) *Expression {
+	return &Expression{
+		Literal: &Token{
+			value: name,
+			kind:  identifierKind,
+		},
+		Kind: LiteralKind,
+	}
+}
+
+// rewrite replaces group keys and aggregate calls in exp with references to the
+// grouped row, names in outputs may still be used as they refer to output columns
+func (gs *groupedScope) rewrite(exp *Expression, outputs map[string]bool) (*Expression, error) {
+	qualified := qualify(exp, gs.schema).String()
+	for i, g := range gs.groupBy {
+		if qualify(g, gs.schema).String() == qualified {
+			return groupedColumnReference(groupedColumnName("group", i)), nil
+		}
+	}
+
+	if exp.isAggregate() {
+		for i, a := range gs.aggregates {
+			if qualify(a, gs.schema).String() == qualified {
+				return groupedColumnReference(groupedColumnName("agg", i)), nil
+			}
+		}
+
+		gs.aggregates = append(gs.aggregates, exp)
+		return groupedColumnReference(groupedColumnName("agg", len(gs.aggregates)-1)), nil
+	}
+
+	if exp.Kind == LiteralKind && exp.Literal.kind == identifierKind {
+		if exp.Table == nil && outputs[exp.Literal.value] {
+			return exp, nil
+		}
+
+		// a reference that doesn't name exactly one column is wrong whether grouped or not
+		if _, err := resolveColumn(exp, gs.schema); err != nil {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("%w: %s", ErrColumnNotGrouped, exp)
+	}
+
+	children := exp.children()
+	if len(children) == 0 {
+		return exp, nil
+	}
+
+	rewritten := make([]*Expression, len(children))
+	for i, child := range children {
+		c, err := gs.rewrite(child, outputs)
+		if err != nil {
+			return nil, err
+		}
+
+		rewritten[i] = c
+	}
+
+	return exp.withChildren(rewritten), nil
+}
+
+// compiledAggregate is an aggregate call whose argument was compiled against the
+// source rows, arg is nil for COUNT(*)
+type compiledAggregate struct {
+	name string
+	arg  *compiledExpression
+	typ  ColumnType
+}
+
+// aggregateState accumulates the rows of one group for one aggregate, sums are kept
+// exact whatever the type being summed
+type aggregateState struct {
+	count int64
+	sum   *big.Rat
+	value MemoryCell
+}
+
+func (mb *MemoryBackend) compileAggregate(call *CallExpression, cols []column) (*compiledAggregate, error) {
+	name := call.Name.value
+
+	// COUNT(*) counts rows rather than values
+	if name == "count" && len(call.Args) == 1 && call.Args[0].isStar() && call.Args[0].Table == nil {
+		return &compiledAggregate{
+			name: name,
+			typ:  BigIntType,
+		}, nil
+	}
+
+	if len(call.Args) != 1 {
+		return nil, fmt.Errorf("%w: %s expects exactly one argument", ErrInvalidExpression, strings.ToUpper(name))
+	}
+
+	arg, err := mb.compileExpression(call.Args[0], cols)
+	if err != nil {
+		return nil, err
+	}
+
+	ca := compiledAggregate{
+		name: name,
+		arg:  arg,
+		typ:  arg.typ,
+	}
+
+	switch name {
+	case "count":
+		ca.typ = BigIntType
+	case "sum", "avg":
+		if !isNumeric(arg.typ) && arg.typ != NullType {
+			return nil, fmt.Errorf("%w: %s expects a number, got %s", ErrTypeMismatch, strings.ToUpper(name), arg.typ)
+		}
+
+		// sums of integers are widened so they don't overflow, the average of integers
+		// keeps its fraction
+		switch {
+		case name == "avg" && (arg.typ == IntType || arg.typ == BigIntType):
+			ca.typ = DecimalType
+		case arg.typ == IntType:
+			ca.typ = BigIntType
+		case arg.typ == BigIntType:
+			ca.typ = DecimalType
+		}
+	}
+
+	return &ca, nil
+}
+
+func (ca *compiledAggregate) add(state *aggregateState, row []MemoryCell) error {
+	if ca.arg == nil {
+		state.count++
+		return nil
+	}
+
+	cell, err := ca.arg.evaluate(row)
+	if err != nil {
+		return err
+	}
+
+	// aggregates skip NULLs
+	if cell.IsNull() {
+		return nil
+	}
+	state.count++
+
+	switch ca.name {
+	case "sum", "avg":
+		r, err := cellToRat(cell, ca.arg.typ)
+		if err != nil {
+			return err
+		}
+
+		if state.sum == nil {
+			state.sum = new(big.Rat)
+		}
+		state.sum.Add(state.sum, r)
+	case "min":
+		if state.count == 1 || compareCells(cell, state.value, ca.typ) < 0 {
+			state.value = cell
+		}
+	case "max":
+		if state.count == 1 || compareCells(cell, state.value, ca.typ) > 0 {
+			state.value = cell
+		}
+	}
+
+	return nil
+}
+
+func (ca *compiledAggregate) result(state *aggregateState) (MemoryCell, error) {
+	if ca.name == "count" {
+		return int64ToCell(state.count), nil
+	}
+
+	// aggregating nothing but COUNT gives NULL
+	if state.count == 0 {
+		return nil, nil
+	}
+
+	switch ca.name {
+	case "sum":
+		return ratToCell(state.sum, ca.typ)
+	case "avg":
+		return ratToCell(new(big.Rat).Quo(state.sum, new(big.Rat).SetInt64(state.count)), ca.typ)
+	}
+
+	return state.value, nil
+}
+
+// groupKey encodes the key cells of a group, length prefixed so that different
+// splits of the same bytes never collide, NULLs get a length of their own
+func groupKey(cells []MemoryCell) string {
+	var sb strings.Builder
+	for _, cell := range cells {
+		if cell.IsNull() {
+			sb.Write(binary.BigEndian.AppendUint32(nil, math.MaxUint32))
+			continue
+		}
+
+		sb.Write(binary.BigEndian.AppendUint32(nil, uint32(len(cell))))
+		sb.Write(cell)
+	}
+
+	return sb.String()
+}
+
+// selectGrouped hash aggregates the rows that pass the WHERE clause by their GROUP BY
+// keys, then selects from one row per group holding its keys and aggregate results
+func (mb *MemoryBackend) selectGrouped(ss *SelectStatement, rows [][]MemoryCell, schema []column) (*Results, error) {
+	where, err := mb.compilePredicate(ss.Where, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	groupBy := []*compiledExpression{}
+	for _, exp := range ss.GroupBy {
+		if exp.containsAggregate() {
+			return nil, fmt.Errorf("%w: aggregates are not allowed in GROUP BY", ErrInvalidExpression)
+		}
+
+		g, err := mb.compileExpression(exp, schema)
+		if err != nil {
+			return nil, err
+		}
+
+		groupBy = append(groupBy, g)
+	}
+
+	scope := groupedScope{
+		schema:  schema,
+		groupBy: ss.GroupBy,
+	}
+
+	grouped := *ss
+	grouped.GroupBy = nil
+	grouped.Having = nil
+	grouped.Item = []*SelectItem{}
+	grouped.OrderBy = []*OrderByItem{}
+
+	outputs := map[string]bool{}
+	for _, item := range ss.Item {
+		if item.Exp.isStar() {
+			return nil, fmt.Errorf("%w: * can't be used with GROUP BY or aggregates", ErrInvalidSelectItem)
+		}
+
+		exp, err := scope.rewrite(item.Exp, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// keep the name the item had before it was rewritten
+		name := item.name()
+		outputs[name] = true
+		grouped.Item = append(grouped.Item, &SelectItem{
+			Exp: exp,
+			As: &Token{
+				value: name,
+				kind:  identifierKind,
+			},
+		})
+	}
+
+	// HAVING filters the grouped rows the way WHERE filters source rows
+	grouped.Where = nil
+	if ss.Having != nil {
+		grouped.Where, err = scope.rewrite(ss.Having, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, item := range ss.OrderBy {
+		exp := item.Exp
+		if exp.Kind != LiteralKind || exp.Literal.kind != integerKind {
+			exp, err = scope.rewrite(exp, outputs)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		grouped.OrderBy = append(grouped.OrderBy, &OrderByItem{
+			Exp:  exp,
+			Desc: item.Desc,
+		})
+	}
+
+	aggregates := []*compiledAggregate{}
+	for _, exp := range scope.aggregates {
+		agg, err := mb.compileAggregate(exp.Call, schema)
+		if err != nil {
+			return nil, err
+		}
+
+		aggregates = append(aggregates, agg)
+	}
+
+	groupedSchema := []column{}
+	for i, g := range groupBy {
+		groupedSchema = append(groupedSchema, column{
+			name: groupedColumnName("group", i),
+			typ:  g.typ,
+		})
+	}
+	for i, agg := range aggregates {
+		groupedSchema = append(groupedSchema, column{
+			name: groupedColumnName("agg", i),
+			typ:  agg.typ,
+		})
+	}
+
+	// groups are kept in the order they are first seen
+	groups := map[string]int{}
+	keys := [][]MemoryCell{}
+	states := [][]aggregateState{}
+	for _, row := range rows {
+		match, err := where.test(row)
+		if err != nil {
+			return nil, err
+		}
+		if !match {
+			continue
+		}
+
+		key := make([]MemoryCell, len(groupBy))
+		for i, g := range groupBy {
+			key[i], err = g.evaluate(row)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		i, ok := groups[groupKey(key)]
+		if !ok {
+			i = len(keys)
+			groups[groupKey(key)] = i
+			keys = append(keys, key)
+			states = append(states, make([]aggregateState, len(aggregates)))
+		}
+
+		for j, agg := range aggregates {
+			if err := agg.add(&states[i][j], row); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// aggregating without GROUP BY always produces one row, even with no input
+	if len(groupBy) == 0 && len(keys) == 0 {
+		keys = append(keys, []MemoryCell{})
+		states = append(states, make([]aggregateState, len(aggregates)))
+	}
+
+	groupedRows := [][]MemoryCell{}
+	for i, key := range keys {
+		row := append([]MemoryCell{}, key...)
+		for j, agg := range aggregates {
+			cell, err := agg.result(&states[i][j])
+			if err != nil {
+				return nil, err
+			}
+
+			row = append(row, cell)
+		}
+
+		groupedRows = append(groupedRows, row)
+	}
+
+	return mb.selectRows(&grouped, groupedRows, groupedSchema)
+}
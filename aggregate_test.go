@@ -0,0 +1,63 @@
+package memsql
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAverageOfIntegersIsDecimal(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table g (k int, v int, b bigint);")
+	mustExecute(t, mb, "insert into g values (1, 1, 1), (1, 2, 2), (2, 1, 1), (2, 1, 1), (2, 2, null);")
+
+	res := mustExecute(t, mb, "select avg(v), avg(b) from g;")
+	if res.Columns[0].Type != DecimalType || res.Columns[1].Type != DecimalType {
+		t.Errorf("got types %s and %s, want decimal", res.Columns[0].Type, res.Columns[1].Type)
+	}
+
+	expectRows(t, mb, "select k, avg(v), avg(b) from g group by k order by k;", [][]string{
+		{"1", "1.5", "1.5"},
+		{"2", "1.3333333333333333", "1"},
+	})
+}
+
+func TestGroupByQualifiedColumns(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table t (a int, b int);")
+	mustExecute(t, mb, "insert into t values (1, 2), (1, 3), (2, 4);")
+
+	expectRows(t, mb, "select t.a, sum(b) from t group by a order by 1;", [][]string{{"1", "5"}, {"2", "4"}})
+	expectRows(t, mb, "select a, count(t.b) from t group by t.a having count(b) > 1;", [][]string{{"1", "2"}})
+	expectRows(t, mb, "select x.a + 1 from t x group by a + 1 order by x.a + 1 desc;", [][]string{{"3"}, {"2"}})
+
+	if _, err := execute(mb, "select b from t group by t.a;"); !errors.Is(err, ErrColumnNotGrouped) {
+		t.Errorf("got %v, want %v", err, ErrColumnNotGrouped)
+	}
+}
+
+func TestSumIsWidened(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table t (v int, b bigint);")
+	mustExecute(t, mb, "insert into t values (2000000000, 9223372036854775807), (2000000000, 9223372036854775807);")
+
+	res := mustExecute(t, mb, "select sum(v), sum(b) from t;")
+	if res.Columns[0].Type != BigIntType || res.Columns[1].Type != DecimalType {
+		t.Errorf("got types %s and %s, want bigint and decimal", res.Columns[0].Type, res.Columns[1].Type)
+	}
+
+	expectRows(t, mb, "select sum(v), sum(b) from t;", [][]string{{"4000000000", "18446744073709551614"}})
+}
+
+func TestCountIsBigint(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table t (v int);")
+	mustExecute(t, mb, "insert into t values (1), (null), (3);")
+
+	res := mustExecute(t, mb, "select count(*), count(v) from t;")
+	if res.Columns[0].Type != BigIntType || res.Columns[1].Type != BigIntType {
+		t.Errorf("got types %s and %s, want bigint", res.Columns[0].Type, res.Columns[1].Type)
+	}
+
+	expectRows(t, mb, "select count(*), count(v) from t;", [][]string{{"3", "2"}})
+	expectRows(t, mb, "select count(*) from t where v > 5;", [][]string{{"0"}})
+}
@@ -0,0 +1,266 @@
+package memsql
+
+import (
+	"fmt"
+)
+
+// AlterTable changes the schema of a table and rewrites its rows to match, either the
+// whole change succeeds or the table is left as it was
+func (mb *MemoryBackend) AlterTable(as *AlterTableStatement) error {
+	table, ok := mb.tables[as.Table.value]
+	if !ok {
+		return ErrTableDoesNotExists
+	}
+
+	// every operation builds new slices, so the old ones can be put back if it fails
+	old := *table
+
+	var err error
+	switch as.Operation {
+	case AddColumnOperation:
+		err = mb.addColumn(table, as.Column)
+	case DropColumnOperation:
+		err = mb.dropColumn(table, as.Name.value)
+	case RenameColumnOperation:
+		err = mb.renameColumn(table, as.Name.value, as.NewName.value)
+	case RenameTableOperation:
+		err = mb.renameTable(table, as.NewName.value)
+	}
+
+	if err != nil {
+		*table = old
+	}
+
+	return err
+}
+
+func columnIndex(table *Table, name string) (int, error) {
+	for i, col := range table.columns {
+		if col == name {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %s.%s", ErrColumnDoesNotExists, table.name, name)
+}
+
+// mapReferences returns exp with every column reference in it replaced by f
+func mapReferences(exp *Expression, f func(ref *Expression) *Expression) *Expression {
+	if exp == nil {
+		return nil
+	}
+
+	if exp.Kind == LiteralKind && exp.Literal.kind == identifierKind {
+		return f(exp)
+	}
+
+	children := exp.children()
+	if len(children) == 0 {
+		return exp
+	}
+
+	mapped := make([]*Expression, len(children))
+	for i, child := range children {
+		mapped[i] = mapReferences(child, f)
+	}
+
+	return exp.withChildren(mapped)
+}
+
+// addColumn adds the column cd declares to table, existing rows get its default
+func (mb *MemoryBackend) addColumn(table *Table, cd *ColumnDefinition) error {
+	if err := table.addColumn(cd); err != nil {
+		return err
+	}
+
+	i := len(table.columns) - 1
+	def, err := mb.compileDefault(table, i)
+	if err != nil {
+		return err
+	}
+
+	if _, err := mb.compilePredicate(cd.Check, table.schema()); err != nil {
+		return err
+	}
+
+	rows := [][]MemoryCell{}
+	for _, row := range table.rows {
+		cell, err := def.evaluate(nil)
+		if err != nil {
+			return err
+		}
+
+		rows = append(rows, append(append([]MemoryCell{}, row...), cell))
+	}
+
+	// the old rows lack the new column, so the rewritten rows are checked on their own
+	table.rows = nil
+	if err := mb.checkConstraints(table, rows, nil); err != nil {
+		return err
+	}
+
+	if cd.References != nil {
+		fk := *cd.References
+		fk.Columns = []Token{cd.Name}
+
+		compiled, err := mb.compileForeignKey(table, &fk)
+		if err != nil {
+			return err
+		}
+		table.foreignKeys = append(append([]*foreignKey{}, table.foreignKeys...), compiled)
+
+		if err := mb.checkReferences(table, rows, nil); err != nil {
+			return err
+		}
+	}
+
+	table.rows = rows
+	return nil
+}
+
+// withoutColumn returns the positions in columns with the ones after dropped moved
+// down by one, reporting false if dropped is one of them
+func withoutColumn(columns []int, dropped int) ([]int, bool) {
+	shifted := []int{}
+	for _, c := range columns {
+		if c == dropped {
+			return nil, false
+		}
+
+		if c > dropped {
+			c--
+		}
+		shifted = append(shifted, c)
+	}
+
+	return shifted, true
+}
+
+// dropColumn removes a column from table and every row, foreign keys of table using
+// it go with it but a column another foreign key references can't be dropped
+func (mb *MemoryBackend) dropColumn(table *Table, name string) error {
+	i, err := columnIndex(table, name)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range mb.sortedTables() {
+		for _, fk := range child.foreignKeys {
+			if _, ok := withoutColumn(fk.parentColumns, i); fk.parent == table && !ok {
+				return fmt.Errorf("%w: %s references column %s", ErrTableReferenced, child.name, name)
+			}
+		}
+	}
+
+	table.columns = append(append([]string{}, table.columns[:i]...), table.columns[i+1:]...)
+	table.columnTypes = append(append([]ColumnType{}, table.columnTypes[:i]...), table.columnTypes[i+1:]...)
+	table.constraints = append(append([]columnConstraints{}, table.constraints[:i]...), table.constraints[i+1:]...)
+
+	// checks of the other columns may not mention the dropped column
+	schema := table.schema()
+	for _, c := range table.constraints {
+		if _, err := mb.compilePredicate(c.check, schema); err != nil {
+			return err
+		}
+	}
+
+	fks := []*foreignKey{}
+	for _, fk := range table.foreignKeys {
+		columns, ok := withoutColumn(fk.columns, i)
+		if !ok {
+			continue
+		}
+
+		fks = append(fks, &foreignKey{
+			columns:       columns,
+			parent:        fk.parent,
+			parentColumns: fk.parentColumns,
+			onDelete:      fk.onDelete,
+		})
+	}
+	table.foreignKeys = fks
+
+	rows := [][]MemoryCell{}
+	for _, row := range table.rows {
+		rows = append(rows, append(append([]MemoryCell{}, row[:i]...), row[i+1:]...))
+	}
+	table.rows = rows
+
+	// nothing can fail anymore, so the keys referencing table can be moved along
+	for _, child := range mb.sortedTables() {
+		for _, fk := range child.foreignKeys {
+			if fk.parent == table {
+				fk.parentColumns, _ = withoutColumn(fk.parentColumns, i)
+			}
+		}
+	}
+
+	return nil
+}
+
+// renameColumn renames a column of table along with the references to it in checks
+func (mb *MemoryBackend) renameColumn(table *Table, from, to string) error {
+	i, err := columnIndex(table, from)
+	if err != nil {
+		return err
+	}
+
+	if _, err := columnIndex(table, to); err == nil {
+		return fmt.Errorf("%w: %s", ErrColumnDuplicated, to)
+	}
+
+	table.columns = append([]string{}, table.columns...)
+	table.columns[i] = to
+
+	table.constraints = append([]columnConstraints{}, table.constraints...)
+	for j, c := range table.constraints {
+		table.constraints[j].check = mapReferences(c.check, func(ref *Expression) *Expression {
+			if ref.Literal.value != from {
+				return ref
+			}
+
+			renamed := *ref
+			renamed.Literal = &Token{
+				value:    to,
+				kind:     identifierKind,
+				location: ref.Literal.location,
+			}
+			return &renamed
+		})
+	}
+
+	return nil
+}
+
+// renameTable registers table under a new name, checks qualified by the old name
+// follow it
+func (mb *MemoryBackend) renameTable(table *Table, to string) error {
+	if _, ok := mb.tables[to]; ok {
+		return fmt.Errorf("%w: %s", ErrTableAlreadyExists, to)
+	}
+
+	from := table.name
+
+	table.constraints = append([]columnConstraints{}, table.constraints...)
+	for j, c := range table.constraints {
+		table.constraints[j].check = mapReferences(c.check, func(ref *Expression) *Expression {
+			if ref.Table == nil || ref.Table.value != from {
+				return ref
+			}
+
+			renamed := *ref
+			renamed.Table = &Token{
+				value:    to,
+				kind:     identifierKind,
+				location: ref.Table.location,
+			}
+			return &renamed
+		})
+	}
+
+	delete(mb.tables, from)
+	mb.tables[to] = table
+	table.name = to
+
+	return nil
+}
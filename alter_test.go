@@ -0,0 +1,87 @@
+package memsql
+
+import (
+	"testing"
+)
+
+func TestFailedAlterTableRollsBack(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table parents (id int primary key);")
+	mustExecute(t, mb, "create table t (a int, b int check (b > a), p int references parents);")
+	mustExecute(t, mb, "insert into parents values (1);")
+	mustExecute(t, mb, "insert into t values (1, 2, 1), (2, 3, null);")
+
+	for _, source := range []string{
+		// fails once the column is added and the rows are rewritten
+		"alter table t add column c int not null;",
+		// fails once the foreign key is added
+		"alter table t add column c int default 2 references parents;",
+		// fails once the column is removed, the check on b still needs it
+		"alter table t drop column a;",
+		"alter table t drop column missing;",
+		"alter table t rename column a to b;",
+		"alter table t rename to parents;",
+	} {
+		if _, err := execute(mb, source); err == nil {
+			t.Fatalf("%s: expected an error", source)
+		}
+
+		expectRows(t, mb, "select * from t;", [][]string{{"1", "2", "1"}, {"2", "3", "NULL"}})
+	}
+
+	// the constraints still hold
+	if _, err := execute(mb, "insert into t values (5, 4, null);"); err == nil {
+		t.Error("expected the check on b to fail")
+	}
+
+	if _, err := execute(mb, "insert into t values (1, 2, 2);"); err == nil {
+		t.Error("expected the foreign key on p to fail")
+	}
+
+	mustExecute(t, mb, "alter table t add column c int default 0;")
+	expectRows(t, mb, "select * from t;", [][]string{{"1", "2", "1", "0"}, {"2", "3", "NULL", "0"}})
+}
+
+func TestAlterTable(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table t (a int, b text);")
+	mustExecute(t, mb, "insert into t values (1, 'x'), (2, 'y');")
+
+	mustExecute(t, mb, "alter table t add column c int default 7;")
+	mustExecute(t, mb, "alter table t add d text;")
+	expectRows(t, mb, "select * from t;", [][]string{{"1", "x", "7", "NULL"}, {"2", "y", "7", "NULL"}})
+
+	mustExecute(t, mb, "alter table t drop column b;")
+	mustExecute(t, mb, "alter table t rename column a to id;")
+	mustExecute(t, mb, "alter table t rename c to score;")
+	mustExecute(t, mb, "alter table t rename to items;")
+	res := mustExecute(t, mb, "select * from items;")
+	expectColumns(t, res, []string{"id", "score", "d"}, []ColumnType{IntType, IntType, TextType})
+	expectRows(t, mb, "select id, score, d from items;", [][]string{{"1", "7", "NULL"}, {"2", "7", "NULL"}})
+
+	expectError(t, mb, "select * from t;", ErrTableDoesNotExists)
+	expectError(t, mb, "alter table t add column e int;", ErrTableDoesNotExists)
+	expectError(t, mb, "alter table items add column id int;", ErrColumnDuplicated)
+	expectError(t, mb, "alter table items drop column missing;", ErrColumnDoesNotExists)
+	expectError(t, mb, "alter table items add column e int default 'x';", ErrTypeMismatch)
+
+	// table names can't be keywords, FROM couldn't refer to them
+	expectError(t, mb, "alter table items rename to key;", nil)
+	expectError(t, mb, "alter table items rename to column;", nil)
+}
+
+func TestColumnToAndAddAsColumnNames(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table moves (to text, add int);")
+	mustExecute(t, mb, "insert into moves (add, to) values (1, 'a');")
+
+	mustExecute(t, mb, "alter table moves add column column int default 2;")
+	expectRows(t, mb, "select to, add, column from moves where add = 1;", [][]string{{"a", "1", "2"}})
+
+	mustExecute(t, mb, "alter table moves rename column to to dest;")
+	mustExecute(t, mb, "alter table moves rename column column to to;")
+	mustExecute(t, mb, "alter table moves drop column add;")
+	res := mustExecute(t, mb, "select * from moves;")
+	expectColumns(t, res, []string{"dest", "to"}, []ColumnType{TextType, IntType})
+	expectRows(t, mb, "select dest, to from moves;", [][]string{{"a", "2"}})
+}
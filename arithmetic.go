@@ -0,0 +1,156 @@
+package memsql
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// arithmetic computes l op r for two non NULL numbers of type typ, integer results
+// are checked against the range of their type and integer division truncates
+func arithmetic(op Symbol, l, r MemoryCell, typ ColumnType) (MemoryCell, error) {
+	if typ == FloatType {
+		x, y := l.AsFloat64(), r.AsFloat64()
+		if (op == slashSymbol || op == percentSymbol) && y == 0 {
+			return nil, ErrDivisionByZero
+		}
+
+		var f float64
+		switch op {
+		case plusSymbol:
+			f = x + y
+		case minusSymbol:
+			f = x - y
+		case asteriskSymbol:
+			f = x * y
+		case slashSymbol:
+			f = x / y
+		case percentSymbol:
+			f = math.Mod(x, y)
+		}
+
+		if math.IsInf(f, 0) && !math.IsInf(x, 0) && !math.IsInf(y, 0) {
+			return nil, fmt.Errorf("%w: %s", ErrNumericOutOfRange, typ)
+		}
+
+		return float64ToCell(f), nil
+	}
+
+	x, err := cellToRat(l, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	y, err := cellToRat(r, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	if (op == slashSymbol || op == percentSymbol) && y.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+
+	z := new(big.Rat)
+	switch op {
+	case plusSymbol:
+		z.Add(x, y)
+	case minusSymbol:
+		z.Sub(x, y)
+	case asteriskSymbol:
+		z.Mul(x, y)
+	case slashSymbol:
+		z.Quo(x, y)
+		if typ != DecimalType {
+			z.SetInt(truncateRat(z))
+		}
+	case percentSymbol:
+		// the remainder takes the sign of the dividend
+		q := new(big.Rat).SetInt(truncateRat(new(big.Rat).Quo(x, y)))
+		z.Sub(x, q.Mul(q, y))
+	}
+
+	return ratToCell(z, typ)
+}
+
+// truncateRat rounds r towards zero
+func truncateRat(r *big.Rat) *big.Int {
+	return new(big.Int).Quo(r.Num(), r.Denom())
+}
+
+// compileArithmetic compiles + - * / % on numbers, the operands are converted to
+// the wider of their types first, + - on dates and times and * / on intervals
+func compileArithmetic(op Symbol, a, b *compiledExpression) (*compiledExpression, error) {
+	if ce, ok := compileTemporalArithmetic(op, a, b); ok {
+		return ce, nil
+	}
+
+	typ, ok := commonType(a.typ, b.typ)
+	if !ok || !(isNumeric(typ) || typ == NullType) {
+		return nil, fmt.Errorf("%w: cannot compute %s %s %s", ErrTypeMismatch, a.typ, op, b.typ)
+	}
+
+	a, b = coerce(a, typ), coerce(b, typ)
+	return &compiledExpression{
+		typ: typ,
+		evaluate: func(row []MemoryCell) (MemoryCell, error) {
+			l, err := a.evaluate(row)
+			if err != nil {
+				return nil, err
+			}
+
+			r, err := b.evaluate(row)
+			if err != nil || l.IsNull() || r.IsNull() {
+				return nil, err
+			}
+
+			return arithmetic(op, l, r, typ)
+		},
+	}, nil
+}
+
+// compileConcat compiles ||, which joins two strings or two blobs
+func compileConcat(a, b *compiledExpression) (*compiledExpression, error) {
+	typ, ok := commonType(a.typ, b.typ)
+	if !ok || !(typ == TextType || typ == BlobType || typ == NullType) {
+		return nil, fmt.Errorf("%w: cannot concatenate %s and %s", ErrTypeMismatch, a.typ, b.typ)
+	}
+
+	return &compiledExpression{
+		typ: typ,
+		evaluate: func(row []MemoryCell) (MemoryCell, error) {
+			l, err := a.evaluate(row)
+			if err != nil {
+				return nil, err
+			}
+
+			r, err := b.evaluate(row)
+			if err != nil || l.IsNull() || r.IsNull() {
+				return nil, err
+			}
+
+			return append(append(MemoryCell{}, l...), r...), nil
+		},
+	}, nil
+}
+
+// negate computes -cell for a non NULL number or interval of type typ
+func negate(cell MemoryCell, typ ColumnType) (MemoryCell, error) {
+	switch typ {
+	case FloatType:
+		return float64ToCell(-cell.AsFloat64()), nil
+	case IntervalType:
+		iv := cell.AsInterval()
+		return intervalToCell(Interval{
+			Months:       -iv.Months,
+			Days:         -iv.Days,
+			Microseconds: -iv.Microseconds,
+		}), nil
+	}
+
+	r, err := cellToRat(cell, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	return ratToCell(r.Neg(r), typ)
+}
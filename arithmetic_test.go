@@ -0,0 +1,51 @@
+package memsql
+
+import "testing"
+
+func TestArithmetic(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table n (a int, b int, big bigint, d decimal, f double precision, s text);")
+	mustExecute(t, mb, "insert into n values (7, 2, 5000000000, 7.5, 0.5, 'ab'), (-7, 2, null, null, null, null);")
+
+	expectRows(t, mb, "select a + b, a - b, a * b, a / b, a % b, -a, - -a from n;", [][]string{
+		{"9", "5", "14", "3", "1", "-7", "7"},
+		{"-5", "-9", "-14", "-3", "-1", "7", "-7"},
+	})
+	expectRows(t, mb, "select 2 + 3 * 4, (2 + 3) * 4, 10 - 4 - 3, 2 * -3;", [][]string{{"14", "20", "3", "-6"}})
+
+	res := mustExecute(t, mb, "select a + big, a * d, d / b, a + f, d % b from n;")
+	expectColumns(t, res, []string{"?column?", "?column?", "?column?", "?column?", "?column?"},
+		[]ColumnType{BigIntType, DecimalType, DecimalType, FloatType, DecimalType})
+	expectRows(t, mb, "select a + big, a * d, d / b, a + f, d % b from n;", [][]string{
+		{"5000000007", "52.5", "3.75", "7.5", "1.5"},
+		{"NULL", "NULL", "NULL", "NULL", "NULL"},
+	})
+
+	expectRows(t, mb, "select s || 'cd', s || null, 'x' || s || 'y' from n;", [][]string{
+		{"abcd", "NULL", "xaby"},
+		{"NULL", "NULL", "NULL"},
+	})
+	expectRows(t, mb, "select a from n where a * 2 + b = 16;", [][]string{{"7"}})
+}
+
+func TestArithmeticErrors(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table n (a int, big bigint, s text, ok boolean);")
+	mustExecute(t, mb, "insert into n values (2147483647, 9223372036854775807, 'a', true);")
+
+	expectError(t, mb, "select a / 0 from n;", ErrDivisionByZero)
+	expectError(t, mb, "select a % 0 from n;", ErrDivisionByZero)
+	expectError(t, mb, "select 1.5 / 0;", ErrDivisionByZero)
+	expectError(t, mb, "select 1e0 / 0;", ErrDivisionByZero)
+
+	expectError(t, mb, "select a + 1 from n;", ErrIntegerOutOfRange)
+	expectError(t, mb, "select -a - 2 from n;", ErrIntegerOutOfRange)
+	expectError(t, mb, "select big * 2 from n;", ErrIntegerOutOfRange)
+	expectError(t, mb, "select -big - 2 from n;", ErrIntegerOutOfRange)
+
+	expectError(t, mb, "select s + 1 from n;", ErrTypeMismatch)
+	expectError(t, mb, "select ok * 2 from n;", ErrTypeMismatch)
+	expectError(t, mb, "select -s from n;", ErrTypeMismatch)
+	expectError(t, mb, "select s || 1 from n;", ErrTypeMismatch)
+	expectError(t, mb, "select a || a from n;", ErrTypeMismatch)
+}
@@ -1,48 +1,460 @@
 package memsql
 
+import "strings"
+
 type AstKind uint
 
 const (
 	SelectKind AstKind = iota
 	CreateTableKind
 	InsertKind
+	UpdateKind
+	DeleteKind
+	DropTableKind
+	TruncateKind
+	AlterTableKind
 )
 
 type ExpressionKind uint
 
 const (
 	LiteralKind ExpressionKind = iota
+	BinaryKind
+	UnaryKind
+	CallKind
+	LikeKind
+	InKind
+	BetweenKind
+	CaseKind
+	CastKind
 )
 
+type BinaryExpression struct {
+	A  *Expression
+	B  *Expression
+	Op Token
+}
+
+type UnaryExpression struct {
+	Operand *Expression
+	Op      Token
+}
+
+type CallExpression struct {
+	Name Token
+	Args []*Expression
+}
+
+// LikeExpression matches Operand against Pattern, Op is LIKE or ILIKE and Escape
+// is nil unless an ESCAPE character was given
+type LikeExpression struct {
+	Operand *Expression
+	Pattern *Expression
+	Escape  *Expression
+	Op      Token
+}
+
+type InExpression struct {
+	Operand *Expression
+	List    []*Expression
+}
+
+type BetweenExpression struct {
+	Operand *Expression
+	Low     *Expression
+	High    *Expression
+}
+
+// CaseExpression picks the Then of the first When that holds, in the simple form
+// with an Operand a When holds if it equals Operand, Else is nil when it's left out
+type CaseExpression struct {
+	Operand *Expression
+	Whens   []*Expression
+	Thens   []*Expression
+	Else    *Expression
+}
+
+// CastExpression converts Operand to the type named by Type
+type CastExpression struct {
+	Operand *Expression
+	Type    Token
+}
+
 type Expression struct {
 	Literal *Token
+	// Table qualifies a column reference literal, as in t.col or t.*
+	Table *Token
+	// Type is the type keyword of a typed text literal, as in DATE '2026-01-01'
+	Type    *Token
+	Binary  *BinaryExpression
+	Unary   *UnaryExpression
+	Call    *CallExpression
+	Like    *LikeExpression
+	In      *InExpression
+	Between *BetweenExpression
+	Case    *CaseExpression
+	Cast    *CastExpression
 	Kind    ExpressionKind
 }
 
+// isStar reports whether exp is * or t.*
+func (exp *Expression) isStar() bool {
+	return exp.Kind == LiteralKind && exp.Literal.kind == symbolKind && Symbol(exp.Literal.value) == asteriskSymbol
+}
+
+// children returns the direct subexpressions of exp
+func (exp *Expression) children() []*Expression {
+	switch exp.Kind {
+	case BinaryKind:
+		return []*Expression{exp.Binary.A, exp.Binary.B}
+	case UnaryKind:
+		return []*Expression{exp.Unary.Operand}
+	case CallKind:
+		return exp.Call.Args
+	case LikeKind:
+		if exp.Like.Escape != nil {
+			return []*Expression{exp.Like.Operand, exp.Like.Pattern, exp.Like.Escape}
+		}
+
+		return []*Expression{exp.Like.Operand, exp.Like.Pattern}
+	case InKind:
+		return append([]*Expression{exp.In.Operand}, exp.In.List...)
+	case BetweenKind:
+		return []*Expression{exp.Between.Operand, exp.Between.Low, exp.Between.High}
+	case CaseKind:
+		children := []*Expression{}
+		if exp.Case.Operand != nil {
+			children = append(children, exp.Case.Operand)
+		}
+
+		children = append(children, exp.Case.Whens...)
+		children = append(children, exp.Case.Thens...)
+		if exp.Case.Else != nil {
+			children = append(children, exp.Case.Else)
+		}
+
+		return children
+	case CastKind:
+		return []*Expression{exp.Cast.Operand}
+	}
+
+	return nil
+}
+
+// withChildren returns a copy of exp with its direct subexpressions replaced, in
+// the order children returns them
+func (exp *Expression) withChildren(children []*Expression) *Expression {
+	cp := *exp
+
+	switch exp.Kind {
+	case BinaryKind:
+		cp.Binary = &BinaryExpression{
+			A:  children[0],
+			B:  children[1],
+			Op: exp.Binary.Op,
+		}
+	case UnaryKind:
+		cp.Unary = &UnaryExpression{
+			Operand: children[0],
+			Op:      exp.Unary.Op,
+		}
+	case CallKind:
+		cp.Call = &CallExpression{
+			Name: exp.Call.Name,
+			Args: children,
+		}
+	case LikeKind:
+		cp.Like = &LikeExpression{
+			Operand: children[0],
+			Pattern: children[1],
+			Op:      exp.Like.Op,
+		}
+
+		if len(children) > 2 {
+			cp.Like.Escape = children[2]
+		}
+	case InKind:
+		cp.In = &InExpression{
+			Operand: children[0],
+			List:    children[1:],
+		}
+	case BetweenKind:
+		cp.Between = &BetweenExpression{
+			Operand: children[0],
+			Low:     children[1],
+			High:    children[2],
+		}
+	case CaseKind:
+		cp.Case = &CaseExpression{}
+		if exp.Case.Operand != nil {
+			cp.Case.Operand, children = children[0], children[1:]
+		}
+
+		n := len(exp.Case.Whens)
+		cp.Case.Whens, cp.Case.Thens = children[:n], children[n:2*n]
+		if exp.Case.Else != nil {
+			cp.Case.Else = children[2*n]
+		}
+	case CastKind:
+		cp.Cast = &CastExpression{
+			Operand: children[0],
+			Type:    exp.Cast.Type,
+		}
+	}
+
+	return &cp
+}
+
+// String renders exp back to SQL, two expressions that render the same are the same
+func (exp *Expression) String() string {
+	switch exp.Kind {
+	case LiteralKind:
+		s := exp.Literal.value
+		switch exp.Literal.kind {
+		case textKind:
+			s = "'" + strings.ReplaceAll(s, "'", "''") + "'"
+		case blobKind:
+			s = "X'" + s + "'"
+		}
+
+		if exp.Table != nil {
+			s = exp.Table.value + "." + s
+		}
+
+		if exp.Type != nil {
+			s = exp.Type.value + " " + s
+		}
+
+		return s
+	case BinaryKind:
+		return "(" + exp.Binary.A.String() + " " + exp.Binary.Op.value + " " + exp.Binary.B.String() + ")"
+	case UnaryKind:
+		return exp.Unary.Op.value + " " + exp.Unary.Operand.String()
+	case CallKind:
+		args := []string{}
+		for _, arg := range exp.Call.Args {
+			args = append(args, arg.String())
+		}
+
+		return exp.Call.Name.value + "(" + strings.Join(args, ", ") + ")"
+	case LikeKind:
+		s := "(" + exp.Like.Operand.String() + " " + exp.Like.Op.value + " " + exp.Like.Pattern.String()
+		if exp.Like.Escape != nil {
+			s += " escape " + exp.Like.Escape.String()
+		}
+
+		return s + ")"
+	case InKind:
+		list := []string{}
+		for _, item := range exp.In.List {
+			list = append(list, item.String())
+		}
+
+		return "(" + exp.In.Operand.String() + " in (" + strings.Join(list, ", ") + "))"
+	case BetweenKind:
+		return "(" + exp.Between.Operand.String() + " between " + exp.Between.Low.String() + " and " + exp.Between.High.String() + ")"
+	case CaseKind:
+		s := "case"
+		if exp.Case.Operand != nil {
+			s += " " + exp.Case.Operand.String()
+		}
+
+		for i, when := range exp.Case.Whens {
+			s += " when " + when.String() + " then " + exp.Case.Thens[i].String()
+		}
+
+		if exp.Case.Else != nil {
+			s += " else " + exp.Case.Else.String()
+		}
+
+		return s + " end"
+	case CastKind:
+		return "cast(" + exp.Cast.Operand.String() + " as " + exp.Cast.Type.value + ")"
+	}
+
+	return ""
+}
+
+// InsertStatement inserts every tuple in Values or every row Select produces, each
+// one holds a value for every column in Columns or for every column of the table
+// when Columns is empty
 type InsertStatement struct {
-	Table  Token
-	Values *[]*Expression
+	Table   Token
+	Columns []Token
+	Values  []*[]*Expression
+	Select  *SelectStatement
+}
+
+// ReferentialAction is what happens to referencing rows when the row they reference
+// is deleted
+type ReferentialAction uint
+
+const (
+	RestrictAction ReferentialAction = iota
+	CascadeAction
+	SetNullAction
+)
+
+// ForeignKey makes Columns reference the rows of Table with the same values in
+// References, which defaults to the primary key of Table
+type ForeignKey struct {
+	Columns    []Token
+	Table      Token
+	References []Token
+	OnDelete   ReferentialAction
 }
 
 type ColumnDefinition struct {
-	Name     Token
-	Datatype Token
+	Name       Token
+	Datatype   Token
+	NotNull    bool
+	Unique     bool
+	PrimaryKey bool
+	Default    *Expression
+	Check      *Expression
+	References *ForeignKey
 }
 
+// CreateTableStatement either declares the columns of a table or creates it from the
+// columns and rows of the As query
 type CreateTableStatement struct {
-	Name    Token
-	Columns *[]*ColumnDefinition
+	Name        Token
+	IfNotExists bool
+	Columns     *[]*ColumnDefinition
+	ForeignKeys []*ForeignKey
+	As          *SelectStatement
+}
+
+type SelectItem struct {
+	Exp *Expression
+	As  *Token
+}
+
+// name is the result column name of the item, its alias if it has one
+func (si *SelectItem) name() string {
+	if si.As != nil {
+		return si.As.value
+	}
+
+	if si.Exp.Kind == LiteralKind && si.Exp.Literal.kind == identifierKind {
+		return si.Exp.Literal.value
+	}
+
+	if si.Exp.Kind == CallKind {
+		return si.Exp.Call.Name.value
+	}
+
+	// a cast keeps the name of a column or function it converts, or is named after
+	// its type
+	if si.Exp.Kind == CastKind {
+		operand := si.Exp.Cast.Operand
+		if name := (&SelectItem{Exp: operand}).name(); name != "?column?" && operand.Kind != CastKind {
+			return name
+		}
+
+		return si.Exp.Cast.Type.value
+	}
+
+	if si.Exp.Kind == CaseKind {
+		return "case"
+	}
+
+	return "?column?"
+}
+
+type OrderByItem struct {
+	Exp  *Expression
+	Desc bool
+}
+
+type JoinType uint
+
+const (
+	InnerJoin JoinType = iota
+	LeftJoin
+	RightJoin
+	CrossJoin
+)
+
+type Join struct {
+	Left  *TableReference
+	Right *TableReference
+	Type  JoinType
+	On    *Expression
+}
+
+// TableReference is either a table, optionally aliased, or a join of two references
+type TableReference struct {
+	Table *Token
+	As    *Token
+	Join  *Join
 }
 
 type SelectStatement struct {
-	Item []*Expression
-	From Token
+	Item    []*SelectItem
+	From    *TableReference
+	Where   *Expression
+	GroupBy []*Expression
+	Having  *Expression
+	OrderBy []*OrderByItem
+	Limit   *Expression
+	Offset  *Expression
+}
+
+type SetClause struct {
+	Column Token
+	Value  *Expression
+}
+
+type UpdateStatement struct {
+	Table Token
+	Set   []*SetClause
+	Where *Expression
+}
+
+type DeleteStatement struct {
+	Table Token
+	Where *Expression
+}
+
+type DropTableStatement struct {
+	Name     Token
+	IfExists bool
+}
+
+type TruncateStatement struct {
+	Table Token
+}
+
+type AlterTableOperation uint
+
+const (
+	AddColumnOperation AlterTableOperation = iota
+	DropColumnOperation
+	RenameColumnOperation
+	RenameTableOperation
+)
+
+// AlterTableStatement changes the schema of Table, Column is the column being added,
+// Name the column being dropped or renamed and NewName the new name of the column or
+// the table
+type AlterTableStatement struct {
+	Table     Token
+	Operation AlterTableOperation
+	Column    *ColumnDefinition
+	Name      Token
+	NewName   Token
 }
 
 type Statement struct {
 	SelectStatement      *SelectStatement
 	CreateTableStatement *CreateTableStatement
 	InsertStatement      *InsertStatement
+	UpdateStatement      *UpdateStatement
+	DeleteStatement      *DeleteStatement
+	DropTableStatement   *DropTableStatement
+	TruncateStatement    *TruncateStatement
+	AlterTableStatement  *AlterTableStatement
 	Kind                 AstKind
 }
 
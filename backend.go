@@ -1,17 +1,88 @@
 package memsql
 
-import "errors"
+import (
+	"errors"
+	"math/big"
+	"time"
+)
 
 type ColumnType uint
 
 const (
 	TextType ColumnType = iota
 	IntType
+	BoolType
+	// NullType is the type of a bare NULL, which fits wherever another type is expected
+	NullType
+	BigIntType
+	FloatType
+	// DecimalType holds exact decimal numbers of any precision
+	DecimalType
+	DateType
+	TimeType
+	TimestampType
+	IntervalType
+	// BlobType holds raw bytes
+	BlobType
+	// JsonType holds a json document
+	JsonType
 )
 
+func (ct ColumnType) String() string {
+	switch ct {
+	case TextType:
+		return "text"
+	case IntType:
+		return "int"
+	case BoolType:
+		return "boolean"
+	case NullType:
+		return "null"
+	case BigIntType:
+		return "bigint"
+	case FloatType:
+		return "double"
+	case DecimalType:
+		return "decimal"
+	case DateType:
+		return "date"
+	case TimeType:
+		return "time"
+	case TimestampType:
+		return "timestamp"
+	case IntervalType:
+		return "interval"
+	case BlobType:
+		return "blob"
+	case JsonType:
+		return "json"
+	}
+
+	return "unknown"
+}
+
+// Interval is a span of time, months and days are kept apart from the rest since
+// how long they are depends on the date they're added to
+type Interval struct {
+	Months       int32
+	Days         int32
+	Microseconds int64
+}
+
 type Cell interface {
 	AsText() string
 	AsInt32() int32
+	AsInt64() int64
+	AsFloat64() float64
+	AsDecimal() *big.Rat
+	AsTime() time.Time
+	AsInterval() Interval
+	AsBytes() []byte
+	// AsJSON decodes a json cell into nil, bool, json.Number, string, []any or
+	// map[string]any
+	AsJSON() any
+	AsBool() bool
+	IsNull() bool
 }
 
 type Results struct {
@@ -23,15 +94,38 @@ type Results struct {
 }
 
 var (
-	ErrTableDoesNotExists  = errors.New("table does not exist")
-	ErrColumnDoesNotExists = errors.New("column does not exist")
-	ErrInvalidSelectItem   = errors.New("select item is not valid")
-	ErrInvalidDatatype     = errors.New("invalid Datatype")
-	ErrMissingValues       = errors.New("missing values")
+	ErrTableDoesNotExists        = errors.New("table does not exist")
+	ErrColumnDoesNotExists       = errors.New("column does not exist")
+	ErrInvalidSelectItem         = errors.New("select item is not valid")
+	ErrInvalidDatatype           = errors.New("invalid Datatype")
+	ErrMissingValues             = errors.New("missing values")
+	ErrInvalidExpression         = errors.New("invalid expression")
+	ErrTypeMismatch              = errors.New("type mismatch")
+	ErrFunctionDoesNotExists     = errors.New("function does not exist")
+	ErrColumnNotGrouped          = errors.New("column must appear in GROUP BY or be used in an aggregate function")
+	ErrIntegerOutOfRange         = errors.New("integer out of range")
+	ErrNumericOutOfRange         = errors.New("numeric value out of range")
+	ErrColumnAmbiguous           = errors.New("column reference is ambiguous")
+	ErrNotNullViolation          = errors.New("null value violates not-null constraint")
+	ErrUniqueViolation           = errors.New("duplicate value violates unique constraint")
+	ErrCheckViolation            = errors.New("row violates check constraint")
+	ErrMultiplePrimaryKeys       = errors.New("multiple primary keys are not allowed")
+	ErrInvalidForeignKey         = errors.New("invalid foreign key")
+	ErrForeignKeyViolation       = errors.New("row violates foreign key constraint")
+	ErrColumnDuplicated          = errors.New("column specified more than once")
+	ErrTableAlreadyExists        = errors.New("table already exists")
+	ErrTableReferenced           = errors.New("table is referenced by a foreign key")
+	ErrDivisionByZero            = errors.New("division by zero")
+	ErrInvalidTextRepresentation = errors.New("invalid input syntax")
 )
 
 type Backend interface {
 	CreateTable(*CreateTableStatement) error
 	Insert(*InsertStatement) error
 	Select(*SelectStatement) (*Results, error)
+	Update(*UpdateStatement) (int, error)
+	Delete(*DeleteStatement) (int, error)
+	DropTable(*DropTableStatement) error
+	Truncate(*TruncateStatement) error
+	AlterTable(*AlterTableStatement) error
 }
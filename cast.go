@@ -0,0 +1,198 @@
+package memsql
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// FormatCell renders a non NULL cell of type typ as text, the way CAST(... AS TEXT)
+// does
+func FormatCell(c Cell, typ ColumnType) string {
+	switch typ {
+	case IntType:
+		return strconv.FormatInt(int64(c.AsInt32()), 10)
+	case BigIntType:
+		return strconv.FormatInt(c.AsInt64(), 10)
+	case FloatType:
+		return strconv.FormatFloat(c.AsFloat64(), 'g', -1, 64)
+	case BoolType:
+		return strconv.FormatBool(c.AsBool())
+	case DateType:
+		return c.AsTime().Format("2006-01-02")
+	case TimeType:
+		return c.AsTime().Format("15:04:05.999999")
+	case TimestampType:
+		return c.AsTime().Format("2006-01-02 15:04:05.999999")
+	case IntervalType:
+		return c.AsInterval().String()
+	case BlobType:
+		return `\x` + hex.EncodeToString(c.AsBytes())
+	}
+
+	return c.AsText()
+}
+
+// canCast reports whether values of type from can be cast to type to, anything
+// can be cast to and from text
+func canCast(from, to ColumnType) bool {
+	switch {
+	case canAssign(from, to), from == TextType, to == TextType, from == JsonType:
+		return true
+	case from == BoolType:
+		return to == IntType || to == BigIntType
+	case to == BoolType:
+		return from == IntType || from == BigIntType
+	case from == TimestampType:
+		return to == TimeType
+	}
+
+	return false
+}
+
+// castCell casts a cell of type from to type to, which canCast must allow
+func castCell(cell MemoryCell, from, to ColumnType) (MemoryCell, error) {
+	if cell.IsNull() || from == to || from == NullType {
+		return cell, nil
+	}
+
+	switch {
+	case to == TextType:
+		return MemoryCell(FormatCell(cell, from)), nil
+	case from == TextType:
+		return parseText(cell.AsText(), to)
+	case from == JsonType:
+		// scalars are read like their text, so '{"n": 1}'::json->'n' can be cast to int
+		text := jsonToText(json.RawMessage(cell))
+		if text.IsNull() {
+			return nil, nil
+		}
+
+		return parseText(text.AsText(), to)
+	case from == BoolType:
+		i := int64(0)
+		if cell.AsBool() {
+			i = 1
+		}
+
+		if to == BigIntType {
+			return int64ToCell(i), nil
+		}
+
+		return int32ToCell(int32(i)), nil
+	case to == BoolType:
+		return boolToCell(cell.AsInt64() != 0), nil
+	case from == TimestampType && to == TimeType:
+		micros := cell.AsInt64()
+		return timeToCell(micros - floorDiv(micros, microsecondsPerDay)*microsecondsPerDay), nil
+	}
+
+	return convertCell(cell, from, to)
+}
+
+// parseText reads s as a value of type typ
+func parseText(s string, typ ColumnType) (MemoryCell, error) {
+	trimmed := strings.TrimSpace(s)
+	invalid := fmt.Errorf("%w for %s: '%s'", ErrInvalidTextRepresentation, typ, s)
+
+	switch typ {
+	case TextType:
+		return MemoryCell(s), nil
+	case IntType, BigIntType:
+		bits := 32
+		if typ == BigIntType {
+			bits = 64
+		}
+
+		i, err := strconv.ParseInt(trimmed, 10, bits)
+		if errors.Is(err, strconv.ErrRange) {
+			return nil, fmt.Errorf("%w: %s is out of range for %s", ErrIntegerOutOfRange, trimmed, typ)
+		}
+
+		if err != nil {
+			return nil, invalid
+		}
+
+		if typ == BigIntType {
+			return int64ToCell(i), nil
+		}
+
+		return int32ToCell(int32(i)), nil
+	case FloatType:
+		f, err := strconv.ParseFloat(trimmed, 64)
+		if errors.Is(err, strconv.ErrRange) && math.IsInf(f, 0) {
+			return nil, fmt.Errorf("%w: %s", ErrNumericOutOfRange, typ)
+		}
+
+		if err != nil && !errors.Is(err, strconv.ErrRange) {
+			return nil, invalid
+		}
+
+		return float64ToCell(f), nil
+	case DecimalType:
+		// Rat also reads fractions like 1/3, which aren't decimals
+		r, ok := new(big.Rat).SetString(trimmed)
+		if !ok || strings.Contains(trimmed, "/") {
+			return nil, invalid
+		}
+
+		return decimalToCell(r), nil
+	case BoolType:
+		switch strings.ToLower(trimmed) {
+		case "t", "true", "y", "yes", "on", "1":
+			return boolToCell(true), nil
+		case "f", "false", "n", "no", "off", "0":
+			return boolToCell(false), nil
+		}
+
+		return nil, invalid
+	case BlobType:
+		if !strings.HasPrefix(s, `\x`) {
+			return MemoryCell(s), nil
+		}
+
+		b, err := hex.DecodeString(s[2:])
+		if err != nil {
+			return nil, invalid
+		}
+
+		return MemoryCell(b), nil
+	}
+
+	return convertCell(MemoryCell(s), TextType, typ)
+}
+
+// compileCast compiles CAST(... AS type) and ...::type
+func (mb *MemoryBackend) compileCast(ce *CastExpression, cols []column) (*compiledExpression, error) {
+	operand, err := mb.compileExpression(ce.Operand, cols)
+	if err != nil {
+		return nil, err
+	}
+
+	to, ok := keywordTypes[Keyword(ce.Type.value)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDatatype, ce.Type.value)
+	}
+
+	from := operand.typ
+	if !canCast(from, to) {
+		return nil, fmt.Errorf("%w: cannot cast %s to %s", ErrTypeMismatch, from, to)
+	}
+
+	return &compiledExpression{
+		typ: to,
+		evaluate: func(row []MemoryCell) (MemoryCell, error) {
+			v, err := operand.evaluate(row)
+			if err != nil {
+				return nil, err
+			}
+
+			return castCell(v, from, to)
+		},
+	}, nil
+}
@@ -0,0 +1,42 @@
+package memsql
+
+import "testing"
+
+func TestCast(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table c (n int, d decimal, f double precision, s text, b boolean, ts timestamp);")
+	mustExecute(t, mb, "insert into c values (42, 2.5, 1.25, ' 17 ', true, '2026-03-04 05:06:07'), (null, -2.5, null, 'yes', false, null);")
+
+	expectRows(t, mb, "select cast(n as text), n::bigint, cast(d as int), f::decimal, cast(s as int), s::text from c where n = 42;", [][]string{
+		{"42", "42", "3", "1.25", "17", " 17 "},
+	})
+	expectRows(t, mb, "select cast(d as int), cast(s as boolean), b::int, 0::boolean from c where n is null;", [][]string{{"-3", "true", "0", "false"}})
+	expectRows(t, mb, "select ts::date, cast(ts as time), '2026-01-02'::date + 1, cast(null as int) from c where n = 42;", [][]string{
+		{"2026-03-04", "05:06:07", "2026-01-03", "NULL"},
+	})
+	expectRows(t, mb, "select cast('1e3' as double precision), '12.50'::decimal, cast('f' as boolean), cast('-9' as bigint);", [][]string{{"1000", "12.5", "false", "-9"}})
+
+	res := mustExecute(t, mb, "select cast(n as bigint), n::text, cast(s as decimal) from c where n = 42;")
+	expectColumns(t, res, []string{"n", "n", "s"}, []ColumnType{BigIntType, TextType, DecimalType})
+}
+
+func TestCastErrors(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table c (n int, big bigint, s text, ts timestamp, d date);")
+	mustExecute(t, mb, "insert into c values (1, 5000000000, 'abc', '2026-01-01 00:00', '2026-01-01');")
+
+	expectError(t, mb, "select cast(s as int) from c;", ErrInvalidTextRepresentation)
+	expectError(t, mb, "select s::boolean from c;", ErrInvalidTextRepresentation)
+	expectError(t, mb, "select 'x'::decimal;", ErrInvalidTextRepresentation)
+	expectError(t, mb, "select '2026-02-30'::date;", ErrInvalidTextRepresentation)
+	expectError(t, mb, "select '\\xzz'::blob;", ErrInvalidTextRepresentation)
+
+	expectError(t, mb, "select cast(big as int) from c;", ErrIntegerOutOfRange)
+	expectError(t, mb, "select '3000000000'::int;", ErrIntegerOutOfRange)
+
+	expectError(t, mb, "select cast(d as time) from c;", ErrTypeMismatch)
+	expectError(t, mb, "select cast(ts as boolean) from c;", ErrTypeMismatch)
+	expectError(t, mb, "select cast(n as date) from c;", ErrTypeMismatch)
+	expectError(t, mb, "select 1.5::boolean;", ErrTypeMismatch)
+	expectError(t, mb, "select cast(n as widget) from c;", nil)
+}
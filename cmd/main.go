@@ -40,6 +40,41 @@ func runRepl(mb *memsql.MemoryBackend, reader *bufio.Reader) {
 				}
 				fmt.Println("OK")
 
+			case memsql.UpdateKind:
+				n, err := mb.Update(stmt.UpdateStatement)
+				if err != nil {
+					panic(err)
+				}
+				fmt.Printf("OK, %d rows affected\n", n)
+
+			case memsql.DeleteKind:
+				n, err := mb.Delete(stmt.DeleteStatement)
+				if err != nil {
+					panic(err)
+				}
+				fmt.Printf("OK, %d rows affected\n", n)
+
+			case memsql.DropTableKind:
+				err := mb.DropTable(stmt.DropTableStatement)
+				if err != nil {
+					panic(err)
+				}
+				fmt.Println("OK")
+
+			case memsql.TruncateKind:
+				err := mb.Truncate(stmt.TruncateStatement)
+				if err != nil {
+					panic(err)
+				}
+				fmt.Println("OK")
+
+			case memsql.AlterTableKind:
+				err := mb.AlterTable(stmt.AlterTableStatement)
+				if err != nil {
+					panic(err)
+				}
+				fmt.Println("OK")
+
 			case memsql.SelectKind:
 				res, err := mb.Select(stmt.SelectStatement)
 				if err != nil {
@@ -60,17 +95,12 @@ func runRepl(mb *memsql.MemoryBackend, reader *bufio.Reader) {
 					fmt.Printf("|")
 
 					for i, cell := range r {
-						typ := res.Columns[i].Type
-						s := ""
-
-						switch typ {
-						case memsql.IntType:
-							s = fmt.Sprintf("%d", cell.AsInt32())
-						case memsql.TextType:
-							s = cell.AsText()
+						if cell.IsNull() {
+							fmt.Print("NULL | ")
+							continue
 						}
 
-						fmt.Printf("%s | ", s)
+						fmt.Printf("%s | ", memsql.FormatCell(cell, res.Columns[i].Type))
 					}
 
 					fmt.Println()
@@ -0,0 +1,155 @@
+package memsql
+
+import (
+	"fmt"
+)
+
+// compileResults compiles the possible results of a conditional expression and
+// converts them to the type they all fit, what describes them for errors
+func (mb *MemoryBackend) compileResults(exps []*Expression, cols []column, what string) ([]*compiledExpression, ColumnType, error) {
+	results := []*compiledExpression{}
+	typ := NullType
+	for _, exp := range exps {
+		ce, err := mb.compileExpression(exp, cols)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		common, ok := commonType(typ, ce.typ)
+		if !ok {
+			return nil, 0, fmt.Errorf("%w: %s types %s and %s cannot be matched", ErrTypeMismatch, what, typ, ce.typ)
+		}
+
+		typ = common
+		results = append(results, ce)
+	}
+
+	for i, ce := range results {
+		results[i] = coerce(ce, typ)
+	}
+
+	return results, typ, nil
+}
+
+// compileCase compiles both forms of CASE, only the result that is picked is
+// evaluated and without an ELSE nothing picked is NULL
+func (mb *MemoryBackend) compileCase(ce *CaseExpression, cols []column) (*compiledExpression, error) {
+	exps := append([]*Expression{}, ce.Thens...)
+	if ce.Else != nil {
+		exps = append(exps, ce.Else)
+	}
+
+	results, typ, err := mb.compileResults(exps, cols, "CASE")
+	if err != nil {
+		return nil, err
+	}
+
+	var els *compiledExpression
+	if ce.Else != nil {
+		els = results[len(results)-1]
+	}
+
+	// the simple form compares the operand to every WHEN, searched WHENs are conditions
+	conditions := []*compiledExpression{}
+	for _, when := range ce.Whens {
+		var cond *compiledExpression
+		var err error
+		if ce.Operand != nil {
+			cond, err = mb.compileBinary(&BinaryExpression{
+				A:  ce.Operand,
+				B:  when,
+				Op: tokenFromSymbol(eqSymbol),
+			}, cols)
+		} else {
+			cond, err = mb.compilePredicate(when, cols)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		conditions = append(conditions, cond)
+	}
+
+	return &compiledExpression{
+		typ: typ,
+		evaluate: func(row []MemoryCell) (MemoryCell, error) {
+			for i, cond := range conditions {
+				ok, err := cond.test(row)
+				if err != nil {
+					return nil, err
+				}
+
+				if ok {
+					return results[i].evaluate(row)
+				}
+			}
+
+			if els == nil {
+				return nil, nil
+			}
+
+			return els.evaluate(row)
+		},
+	}, nil
+}
+
+// compileCoalesce compiles COALESCE, the first of its arguments that isn't NULL,
+// the arguments after it aren't evaluated
+func (mb *MemoryBackend) compileCoalesce(args []*Expression, cols []column) (*compiledExpression, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%w: COALESCE expects at least one argument", ErrInvalidExpression)
+	}
+
+	compiled, typ, err := mb.compileResults(args, cols, "COALESCE")
+	if err != nil {
+		return nil, err
+	}
+
+	return &compiledExpression{
+		typ: typ,
+		evaluate: func(row []MemoryCell) (MemoryCell, error) {
+			for _, arg := range compiled {
+				v, err := arg.evaluate(row)
+				if err != nil || !v.IsNull() {
+					return v, err
+				}
+			}
+
+			return nil, nil
+		},
+	}, nil
+}
+
+// compileNullif compiles NULLIF(a, b), which is NULL when a equals b and a otherwise
+func (mb *MemoryBackend) compileNullif(args []*Expression, cols []column) (*compiledExpression, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("%w: NULLIF expects 2 arguments, got %d", ErrInvalidExpression, len(args))
+	}
+
+	value, err := mb.compileExpression(args[0], cols)
+	if err != nil {
+		return nil, err
+	}
+
+	equal, err := mb.compileBinary(&BinaryExpression{
+		A:  args[0],
+		B:  args[1],
+		Op: tokenFromSymbol(eqSymbol),
+	}, cols)
+	if err != nil {
+		return nil, err
+	}
+
+	return &compiledExpression{
+		typ: value.typ,
+		evaluate: func(row []MemoryCell) (MemoryCell, error) {
+			ok, err := equal.test(row)
+			if err != nil || ok {
+				return nil, err
+			}
+
+			return value.evaluate(row)
+		},
+	}, nil
+}
@@ -0,0 +1,57 @@
+package memsql
+
+import "testing"
+
+func TestCase(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table s (id int, score int, grade text);")
+	mustExecute(t, mb, "insert into s values (1, 95, 'a'), (2, 70, 'c'), (3, 40, null), (4, null, 'b');")
+
+	expectRows(t, mb, "select id, case when score >= 90 then 'top' when score >= 50 then 'pass' else 'fail' end from s;", [][]string{
+		{"1", "top"}, {"2", "pass"}, {"3", "fail"}, {"4", "fail"},
+	})
+	expectRows(t, mb, "select id, case grade when 'a' then 4 when 'b' then 3 end from s;", [][]string{
+		{"1", "4"}, {"2", "NULL"}, {"3", "NULL"}, {"4", "3"},
+	})
+
+	// results are converted to the type they all fit and only the picked one is evaluated
+	res := mustExecute(t, mb, "select case when id = 1 then 1 when id = 2 then 2.5 else null end from s;")
+	expectColumns(t, res, []string{"case"}, []ColumnType{DecimalType})
+	expectRows(t, mb, "select case when id = 1 then 1 when id = 2 then 2.5 end from s;", [][]string{{"1"}, {"2.5"}, {"NULL"}, {"NULL"}})
+	expectRows(t, mb, "select case when score = 40 then 0 else 100 / (score - 40) end from s where id > 2;", [][]string{{"0"}, {"NULL"}})
+	expectRows(t, mb, "select id from s where case when grade is null then true else false end;", [][]string{{"3"}})
+}
+
+func TestCoalesceAndNullif(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table s (id int, a int, b bigint, t text);")
+	mustExecute(t, mb, "insert into s values (1, 1, 10, 'x'), (2, null, 20, ''), (3, null, null, null);")
+
+	expectRows(t, mb, "select coalesce(a, b, 0), coalesce(t, '-') from s;", [][]string{{"1", "x"}, {"20", ""}, {"0", "-"}})
+	res := mustExecute(t, mb, "select coalesce(a, b) from s;")
+	expectColumns(t, res, []string{"coalesce"}, []ColumnType{BigIntType})
+	expectRows(t, mb, "select coalesce(a, 1 / 0) from s where id = 1;", [][]string{{"1"}})
+
+	expectRows(t, mb, "select nullif(t, ''), nullif(a, 1), nullif(b, 20) from s;", [][]string{
+		{"x", "NULL", "10"},
+		{"NULL", "NULL", "NULL"},
+		{"NULL", "NULL", "NULL"},
+	})
+	expectRows(t, mb, "select coalesce(nullif(t, ''), 'empty') from s;", [][]string{{"x"}, {"empty"}, {"empty"}})
+}
+
+func TestConditionalErrors(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table s (id int, t text);")
+	mustExecute(t, mb, "insert into s values (1, 'x');")
+
+	expectError(t, mb, "select case when id = 1 then 1 else 'one' end from s;", ErrTypeMismatch)
+	expectError(t, mb, "select case when id then 1 end from s;", ErrTypeMismatch)
+	expectError(t, mb, "select case id when 'a' then 1 end from s;", ErrTypeMismatch)
+	expectError(t, mb, "select case when id = 1 then 1 / 0 end from s;", ErrDivisionByZero)
+	expectError(t, mb, "select coalesce(id, t) from s;", ErrTypeMismatch)
+	expectError(t, mb, "select coalesce() from s;", nil)
+	expectError(t, mb, "select nullif(id, t) from s;", ErrTypeMismatch)
+	expectError(t, mb, "select nullif(id) from s;", nil)
+	expectError(t, mb, "select case end from s;", nil)
+}
@@ -0,0 +1,206 @@
+package memsql
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// numericRank orders the numeric types from narrowest to widest, a value of one of
+// them can be widened to any type that ranks higher, other types rank 0
+func numericRank(typ ColumnType) int {
+	switch typ {
+	case IntType:
+		return 1
+	case BigIntType:
+		return 2
+	case DecimalType:
+		return 3
+	case FloatType:
+		return 4
+	}
+
+	return 0
+}
+
+func isNumeric(typ ColumnType) bool {
+	return numericRank(typ) > 0
+}
+
+// commonType is the type two values are converted to before they are compared or
+// combined, reporting false when no such type exists
+func commonType(a, b ColumnType) (ColumnType, bool) {
+	switch {
+	case a == b || b == NullType:
+		return a, true
+	case a == NullType:
+		return b, true
+	case isNumeric(a) && isNumeric(b):
+		if numericRank(a) > numericRank(b) {
+			return a, true
+		}
+
+		return b, true
+	case (a == DateType && b == TimestampType) || (a == TimestampType && b == DateType):
+		return TimestampType, true
+	}
+
+	return a, false
+}
+
+// canAssign reports whether a value of type typ can be stored in a column of type
+// want, numbers convert to any other numeric type on the way in, dates and
+// timestamps to each other and text is parsed into dates, times and json
+func canAssign(typ, want ColumnType) bool {
+	switch {
+	case fitsType(typ, want), isNumeric(typ) && isNumeric(want):
+		return true
+	case typ == TextType:
+		return isTemporal(want) || want == JsonType
+	case typ == DateType:
+		return want == TimestampType
+	case typ == TimestampType:
+		return want == DateType
+	}
+
+	return false
+}
+
+// roundRat rounds r to the nearest integer, halves away from zero
+func roundRat(r *big.Rat) *big.Int {
+	half := big.NewRat(1, 2)
+	if r.Sign() < 0 {
+		half.Neg(half)
+	}
+
+	rounded := new(big.Rat).Add(r, half)
+	return new(big.Int).Quo(rounded.Num(), rounded.Denom())
+}
+
+// cellToRat reads a numeric cell of type typ as an exact rational
+func cellToRat(cell MemoryCell, typ ColumnType) (*big.Rat, error) {
+	switch typ {
+	case IntType, BigIntType:
+		return new(big.Rat).SetInt64(cell.AsInt64()), nil
+	case DecimalType:
+		return cell.AsDecimal(), nil
+	case FloatType:
+		f := cell.AsFloat64()
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, fmt.Errorf("%w: %g can't be converted to an exact number", ErrTypeMismatch, f)
+		}
+
+		return new(big.Rat).SetFloat64(f), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s is not a number", ErrTypeMismatch, typ)
+}
+
+// ratToCell stores r as a cell of the numeric type typ, integers are rounded and
+// checked against the range of their type
+func ratToCell(r *big.Rat, typ ColumnType) (MemoryCell, error) {
+	switch typ {
+	case IntType, BigIntType:
+		i := roundRat(r)
+		if !i.IsInt64() {
+			return nil, fmt.Errorf("%w: %s is out of range for %s", ErrIntegerOutOfRange, i, typ)
+		}
+
+		if typ == BigIntType {
+			return int64ToCell(i.Int64()), nil
+		}
+
+		if i.Int64() > math.MaxInt32 || i.Int64() < math.MinInt32 {
+			return nil, fmt.Errorf("%w: %s is out of range for %s", ErrIntegerOutOfRange, i, typ)
+		}
+
+		return int32ToCell(int32(i.Int64())), nil
+	case DecimalType:
+		return decimalToCell(r), nil
+	case FloatType:
+		f, _ := r.Float64()
+		if math.IsInf(f, 0) {
+			return nil, fmt.Errorf("%w: %s", ErrNumericOutOfRange, typ)
+		}
+
+		return float64ToCell(f), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s is not a number", ErrTypeMismatch, typ)
+}
+
+// convertCell converts a cell of type from to type to, which must be a conversion
+// canAssign allows
+func convertCell(cell MemoryCell, from, to ColumnType) (MemoryCell, error) {
+	if cell.IsNull() || from == to || from == NullType {
+		return cell, nil
+	}
+
+	switch {
+	case from == TextType && to == JsonType:
+		return parseJSON(cell.AsText())
+	case from == TextType:
+		return parseTemporal(cell.AsText(), to)
+	case from == DateType && to == TimestampType:
+		return int64ToCell(int64(cell.AsInt32()) * microsecondsPerDay), nil
+	case from == TimestampType && to == DateType:
+		return int32ToCell(int32(floorDiv(cell.AsInt64(), microsecondsPerDay))), nil
+	}
+
+	// widening an integer never needs the detour through a rational
+	switch {
+	case from == IntType && to == BigIntType:
+		return int64ToCell(cell.AsInt64()), nil
+	case from == IntType || from == BigIntType:
+		if to == FloatType {
+			return float64ToCell(float64(cell.AsInt64())), nil
+		}
+	}
+
+	r, err := cellToRat(cell, from)
+	if err != nil {
+		return nil, err
+	}
+
+	return ratToCell(r, to)
+}
+
+// coerce converts the values of ce to typ, which ce's type must be convertible to
+func coerce(ce *compiledExpression, typ ColumnType) *compiledExpression {
+	if ce.typ == typ || ce.typ == NullType {
+		return ce
+	}
+
+	from := ce.typ
+	return &compiledExpression{
+		typ: typ,
+		evaluate: func(row []MemoryCell) (MemoryCell, error) {
+			v, err := ce.evaluate(row)
+			if err != nil {
+				return nil, err
+			}
+
+			return convertCell(v, from, typ)
+		},
+	}
+}
+
+// coerceLiteral converts ce, compiled from exp, to the temporal type typ when exp
+// is a plain string, so d > '2026-02-01' compares dates
+func coerceLiteral(exp *Expression, ce *compiledExpression, typ ColumnType) (*compiledExpression, error) {
+	if !isTemporal(typ) || exp.Kind != LiteralKind || exp.Literal.kind != textKind || exp.Type != nil {
+		return ce, nil
+	}
+
+	v, err := convertCell(MemoryCell(exp.Literal.value), TextType, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	return &compiledExpression{
+		typ: typ,
+		evaluate: func(row []MemoryCell) (MemoryCell, error) {
+			return v, nil
+		},
+	}, nil
+}
@@ -0,0 +1,461 @@
+package memsql
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// column is a named, typed position in the rows an expression is evaluated against
+type column struct {
+	table string
+	name  string
+	typ   ColumnType
+}
+
+// compiledExpression is an expression whose column references and types were
+// resolved once up front, so evaluating it for each row needs no lookups
+type compiledExpression struct {
+	typ      ColumnType
+	evaluate func(row []MemoryCell) (MemoryCell, error)
+}
+
+// test evaluates a boolean expression against a row, a missing predicate matches
+// every row and a NULL result matches none
+func (ce *compiledExpression) test(row []MemoryCell) (bool, error) {
+	if ce == nil {
+		return true, nil
+	}
+
+	v, err := ce.evaluate(row)
+	if err != nil {
+		return false, err
+	}
+
+	return v.AsBool(), nil
+}
+
+var comparisons = map[Symbol]func(int) bool{
+	eqSymbol:  func(c int) bool { return c == 0 },
+	neqSymbol: func(c int) bool { return c != 0 },
+	ltSymbol:  func(c int) bool { return c < 0 },
+	lteSymbol: func(c int) bool { return c <= 0 },
+	gtSymbol:  func(c int) bool { return c > 0 },
+	gteSymbol: func(c int) bool { return c >= 0 },
+}
+
+// compareCells orders two cells of the same column type, numbers numerically, text
+// lexically and false before true, cells without a value come last
+func compareCells(a, b MemoryCell, typ ColumnType) int {
+	if a.IsNull() || b.IsNull() {
+		switch {
+		case a.IsNull() && b.IsNull():
+			return 0
+		case a.IsNull():
+			return 1
+		}
+		return -1
+	}
+
+	switch typ {
+	case IntType, BigIntType:
+		x, y := a.AsInt64(), b.AsInt64()
+		if x < y {
+			return -1
+		}
+		if x > y {
+			return 1
+		}
+		return 0
+	case FloatType:
+		x, y := a.AsFloat64(), b.AsFloat64()
+		if x < y {
+			return -1
+		}
+		if x > y {
+			return 1
+		}
+		return 0
+	case DecimalType:
+		return a.AsDecimal().Cmp(b.AsDecimal())
+	case DateType, TimeType, TimestampType:
+		x, y := a.AsTime(), b.AsTime()
+		return x.Compare(y)
+	case IntervalType:
+		return compareIntervals(a.AsInterval(), b.AsInterval())
+	case BlobType:
+		return bytes.Compare(a.AsBytes(), b.AsBytes())
+	case BoolType:
+		x, y := a.AsBool(), b.AsBool()
+		if x == y {
+			return 0
+		}
+		if !x {
+			return -1
+		}
+		return 1
+	}
+
+	return strings.Compare(a.AsText(), b.AsText())
+}
+
+// columnReference reads the cell at position i of every row
+func columnReference(i int, typ ColumnType) *compiledExpression {
+	return &compiledExpression{
+		typ: typ,
+		evaluate: func(row []MemoryCell) (MemoryCell, error) {
+			return row[i], nil
+		},
+	}
+}
+
+// resolveColumn finds the position in cols of the column the reference exp names
+func resolveColumn(exp *Expression, cols []column) (int, error) {
+	found := -1
+	for i, col := range cols {
+		if col.name == exp.Literal.value && (exp.Table == nil || exp.Table.value == col.table) {
+			if found >= 0 {
+				return 0, fmt.Errorf("%w: %s", ErrColumnAmbiguous, exp)
+			}
+
+			found = i
+		}
+	}
+
+	if found < 0 {
+		return 0, ErrColumnDoesNotExists
+	}
+
+	return found, nil
+}
+
+// fitsType reports whether a value of type typ can be used where want is expected
+func fitsType(typ, want ColumnType) bool {
+	return typ == want || typ == NullType
+}
+
+func (mb *MemoryBackend) compileExpression(exp *Expression, cols []column) (*compiledExpression, error) {
+	switch exp.Kind {
+	case LiteralKind:
+		return mb.compileLiteral(exp, cols)
+	case UnaryKind:
+		return mb.compileUnary(exp.Unary, cols)
+	case BinaryKind:
+		return mb.compileBinary(exp.Binary, cols)
+	case CallKind:
+		name := exp.Call.Name.value
+		if aggregateFunctions[name] {
+			return nil, fmt.Errorf("%w: aggregate %s is not allowed here", ErrInvalidExpression, strings.ToUpper(name))
+		}
+
+		return mb.compileCall(exp.Call, cols)
+	case LikeKind:
+		return mb.compileLike(exp.Like, cols)
+	case InKind:
+		return mb.compileIn(exp.In, cols)
+	case BetweenKind:
+		return mb.compileBetween(exp.Between, cols)
+	case CaseKind:
+		return mb.compileCase(exp.Case, cols)
+	case CastKind:
+		return mb.compileCast(exp.Cast, cols)
+	}
+
+	return nil, ErrInvalidExpression
+}
+
+// compilePredicate compiles an optional boolean condition such as a WHERE clause
+func (mb *MemoryBackend) compilePredicate(exp *Expression, cols []column) (*compiledExpression, error) {
+	if exp == nil {
+		return nil, nil
+	}
+
+	ce, err := mb.compileExpression(exp, cols)
+	if err != nil {
+		return nil, err
+	}
+
+	if !fitsType(ce.typ, BoolType) {
+		return nil, fmt.Errorf("%w: condition must be boolean, got %s", ErrTypeMismatch, ce.typ)
+	}
+
+	return ce, nil
+}
+
+func (mb *MemoryBackend) compileLiteral(exp *Expression, cols []column) (*compiledExpression, error) {
+	lit := exp.Literal
+
+	switch lit.kind {
+	case identifierKind:
+		i, err := resolveColumn(exp, cols)
+		if err != nil {
+			return nil, err
+		}
+
+		return columnReference(i, cols[i].typ), nil
+
+	case textKind:
+		if exp.Type == nil {
+			break
+		}
+
+		// typed literals are parsed once, so a bad one fails before any row is touched
+		typ := keywordTypes[Keyword(exp.Type.value)]
+		cell, err := convertCell(MemoryCell(lit.value), TextType, typ)
+		if err != nil {
+			return nil, err
+		}
+
+		return &compiledExpression{
+			typ: typ,
+			evaluate: func([]MemoryCell) (MemoryCell, error) {
+				return cell, nil
+			},
+		}, nil
+	}
+
+	switch lit.kind {
+	case blobKind:
+		b, err := hex.DecodeString(lit.value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid hex string X'%s'", ErrInvalidExpression, lit.value)
+		}
+
+		cell := MemoryCell(b)
+		return &compiledExpression{
+			typ: BlobType,
+			evaluate: func([]MemoryCell) (MemoryCell, error) {
+				return cell, nil
+			},
+		}, nil
+
+	case integerKind, decimalKind, textKind:
+		typ := TextType
+		switch lit.kind {
+		case integerKind:
+			// integers too big for an int are bigints
+			typ = IntType
+			if _, err := strconv.ParseInt(lit.value, 10, 32); err != nil {
+				typ = BigIntType
+			}
+		case decimalKind:
+			typ = DecimalType
+		}
+
+		cell, err := mb.tokenToCell(lit)
+		if err != nil {
+			return nil, err
+		}
+
+		return &compiledExpression{
+			typ: typ,
+			evaluate: func([]MemoryCell) (MemoryCell, error) {
+				return cell, nil
+			},
+		}, nil
+
+	case keywordKind:
+		if Keyword(lit.value) == defaultKeyword {
+			return nil, fmt.Errorf("%w: DEFAULT is only allowed as an inserted or updated value", ErrInvalidExpression)
+		}
+
+		if Keyword(lit.value) == trueKeyword || Keyword(lit.value) == falseKeyword {
+			cell, err := mb.tokenToCell(lit)
+			if err != nil {
+				return nil, err
+			}
+
+			return &compiledExpression{
+				typ: BoolType,
+				evaluate: func([]MemoryCell) (MemoryCell, error) {
+					return cell, nil
+				},
+			}, nil
+		}
+
+		if Keyword(lit.value) == nullKeyword {
+			return &compiledExpression{
+				typ: NullType,
+				evaluate: func([]MemoryCell) (MemoryCell, error) {
+					return nil, nil
+				},
+			}, nil
+		}
+
+	case symbolKind:
+		if Symbol(lit.value) == asteriskSymbol {
+			return nil, fmt.Errorf("%w: * is only allowed in the select list", ErrInvalidExpression)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: unexpected %s", ErrInvalidExpression, lit.value)
+}
+
+func (mb *MemoryBackend) compileUnary(ue *UnaryExpression, cols []column) (*compiledExpression, error) {
+	operand, err := mb.compileExpression(ue.Operand, cols)
+	if err != nil {
+		return nil, err
+	}
+
+	if ue.Op.kind == keywordKind && Keyword(ue.Op.value) == notKeyword {
+		if !fitsType(operand.typ, BoolType) {
+			return nil, fmt.Errorf("%w: NOT expects a boolean, got %s", ErrTypeMismatch, operand.typ)
+		}
+
+		return &compiledExpression{
+			typ: BoolType,
+			evaluate: func(row []MemoryCell) (MemoryCell, error) {
+				v, err := operand.evaluate(row)
+				if err != nil || v.IsNull() {
+					return nil, err
+				}
+
+				return boolToCell(!v.AsBool()), nil
+			},
+		}, nil
+	}
+
+	if ue.Op.kind == symbolKind && Symbol(ue.Op.value) == minusSymbol {
+		typ := operand.typ
+		if !isNumeric(typ) && typ != IntervalType && typ != NullType {
+			return nil, fmt.Errorf("%w: cannot negate %s", ErrTypeMismatch, typ)
+		}
+
+		return &compiledExpression{
+			typ: typ,
+			evaluate: func(row []MemoryCell) (MemoryCell, error) {
+				v, err := operand.evaluate(row)
+				if err != nil || v.IsNull() {
+					return nil, err
+				}
+
+				return negate(v, typ)
+			},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown operator %s", ErrInvalidExpression, ue.Op.value)
+}
+
+func (mb *MemoryBackend) compileBinary(be *BinaryExpression, cols []column) (*compiledExpression, error) {
+	a, err := mb.compileExpression(be.A, cols)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := mb.compileExpression(be.B, cols)
+	if err != nil {
+		return nil, err
+	}
+
+	op := be.Op.value
+
+	if be.Op.kind == keywordKind && Keyword(op) == isKeyword {
+		return &compiledExpression{
+			typ: BoolType,
+			evaluate: func(row []MemoryCell) (MemoryCell, error) {
+				v, err := a.evaluate(row)
+				if err != nil {
+					return nil, err
+				}
+
+				return boolToCell(v.IsNull()), nil
+			},
+		}, nil
+	}
+
+	if be.Op.kind == keywordKind && (Keyword(op) == andKeyword || Keyword(op) == orKeyword) {
+		if !fitsType(a.typ, BoolType) || !fitsType(b.typ, BoolType) {
+			return nil, fmt.Errorf("%w: %s expects boolean operands, got %s and %s", ErrTypeMismatch, strings.ToUpper(op), a.typ, b.typ)
+		}
+
+		// false decides an AND and true decides an OR, otherwise NULL wins
+		decisive := Keyword(op) == orKeyword
+		return &compiledExpression{
+			typ: BoolType,
+			evaluate: func(row []MemoryCell) (MemoryCell, error) {
+				l, err := a.evaluate(row)
+				if err != nil {
+					return nil, err
+				}
+
+				if !l.IsNull() && l.AsBool() == decisive {
+					return l, nil
+				}
+
+				r, err := b.evaluate(row)
+				if err != nil {
+					return nil, err
+				}
+
+				if !r.IsNull() && r.AsBool() == decisive {
+					return r, nil
+				}
+
+				if l.IsNull() || r.IsNull() {
+					return nil, nil
+				}
+
+				return boolToCell(!decisive), nil
+			},
+		}, nil
+	}
+
+	if test, ok := comparisons[Symbol(op)]; ok && be.Op.kind == symbolKind {
+		if a, err = coerceLiteral(be.A, a, b.typ); err != nil {
+			return nil, err
+		}
+
+		if b, err = coerceLiteral(be.B, b, a.typ); err != nil {
+			return nil, err
+		}
+
+		// numbers of different types are compared as the wider type
+		typ, ok := commonType(a.typ, b.typ)
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot compare %s with %s", ErrTypeMismatch, a.typ, b.typ)
+		}
+
+		a, b := coerce(a, typ), coerce(b, typ)
+		return &compiledExpression{
+			typ: BoolType,
+			evaluate: func(row []MemoryCell) (MemoryCell, error) {
+				l, err := a.evaluate(row)
+				if err != nil {
+					return nil, err
+				}
+
+				r, err := b.evaluate(row)
+				if err != nil {
+					return nil, err
+				}
+
+				// comparing with NULL is unknown
+				if l.IsNull() || r.IsNull() {
+					return nil, nil
+				}
+
+				return boolToCell(test(compareCells(l, r, typ))), nil
+			},
+		}, nil
+	}
+
+	if be.Op.kind == symbolKind && (Symbol(op) == arrowSymbol || Symbol(op) == longArrowSymbol) {
+		return compileJSONOperator(Symbol(op), a, b)
+	}
+
+	if be.Op.kind == symbolKind {
+		switch Symbol(op) {
+		case plusSymbol, minusSymbol, asteriskSymbol, slashSymbol, percentSymbol:
+			return compileArithmetic(Symbol(op), a, b)
+		case concatSymbol:
+			return compileConcat(a, b)
+		case matchSymbol, imatchSymbol, notMatchSymbol, notImatchSymbol:
+			return compileMatch(Symbol(op), a, b)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: unknown operator %s", ErrInvalidExpression, op)
+}
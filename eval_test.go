@@ -0,0 +1,122 @@
+package memsql
+
+import (
+	"testing"
+)
+
+func TestWhere(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table users (name text, age int);")
+	mustExecute(t, mb, "insert into users values ('ann', 30), ('bob', 41), ('cy', 17), ('dee', 41);")
+
+	expectRows(t, mb, "select name from users where age = 41;", [][]string{{"bob"}, {"dee"}})
+	expectRows(t, mb, "select name from users where age <> 41;", [][]string{{"ann"}, {"cy"}})
+	expectRows(t, mb, "select name from users where age < 30;", [][]string{{"cy"}})
+	expectRows(t, mb, "select name from users where age <= 30;", [][]string{{"ann"}, {"cy"}})
+	expectRows(t, mb, "select name from users where age > 30;", [][]string{{"bob"}, {"dee"}})
+	expectRows(t, mb, "select name from users where age >= 41 and name > 'c';", [][]string{{"dee"}})
+	expectRows(t, mb, "select name from users where 18 > age;", [][]string{{"cy"}})
+	expectRows(t, mb, "select name from users where name = 'ann' or name = 'cy';", [][]string{{"ann"}, {"cy"}})
+	expectRows(t, mb, "select name from users where not age = 41;", [][]string{{"ann"}, {"cy"}})
+
+	// AND binds tighter than OR and NOT tighter than AND
+	expectRows(t, mb, "select name from users where name = 'ann' or age = 41 and name = 'dee';", [][]string{{"ann"}, {"dee"}})
+	expectRows(t, mb, "select name from users where (name = 'ann' or age = 41) and name <> 'dee';", [][]string{{"ann"}, {"bob"}})
+	expectRows(t, mb, "select name from users where not age = 41 and age > 20;", [][]string{{"ann"}})
+
+	expectRows(t, mb, "select name from users where age > 100;", [][]string{})
+	expectRows(t, mb, "select name from users where true;", [][]string{{"ann"}, {"bob"}, {"cy"}, {"dee"}})
+}
+
+func TestWhereErrors(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table users (name text, age int);")
+
+	expectError(t, mb, "select name from users where age = 'x';", ErrTypeMismatch)
+	expectError(t, mb, "select name from users where age;", ErrTypeMismatch)
+	expectError(t, mb, "select name from users where age > 1 and name;", ErrTypeMismatch)
+	expectError(t, mb, "select name from users where not name;", ErrTypeMismatch)
+	expectError(t, mb, "select name from users where height > 1;", ErrColumnDoesNotExists)
+	expectError(t, mb, "select name from users where age >;", nil)
+	expectError(t, mb, "select name from users where (age > 1;", nil)
+}
+
+func TestThreeValuedLogic(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table b (id int, p boolean, q boolean);")
+	mustExecute(t, mb, "insert into b values (1, true, null), (2, false, null), (3, null, null), (4, true, true);")
+
+	expectRows(t, mb, "select id, p and q, p or q, not q from b;", [][]string{
+		{"1", "NULL", "true", "NULL"},
+		{"2", "false", "NULL", "NULL"},
+		{"3", "NULL", "NULL", "NULL"},
+		{"4", "true", "true", "false"},
+	})
+
+	// WHERE keeps only rows whose condition is true, unknown filters like false
+	expectRows(t, mb, "select id from b where p or q;", [][]string{{"1"}, {"4"}})
+	expectRows(t, mb, "select id from b where not (p and q);", [][]string{{"2"}})
+	expectRows(t, mb, "select id from b where q is null;", [][]string{{"1"}, {"2"}, {"3"}})
+	expectRows(t, mb, "select id from b where p is not null and q is null;", [][]string{{"1"}, {"2"}})
+}
+
+func TestNullComparisons(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table users (name text, age int);")
+	mustExecute(t, mb, "insert into users values ('ann', 30), ('bob', null), (null, 17);")
+
+	expectRows(t, mb, "select name from users where age = null;", [][]string{})
+	expectRows(t, mb, "select name from users where age <> 30;", [][]string{{"NULL"}})
+	expectRows(t, mb, "select name from users where not age > 20;", [][]string{{"NULL"}})
+	expectRows(t, mb, "select name, age = null, age is null, null is null from users;", [][]string{
+		{"ann", "NULL", "false", "true"},
+		{"bob", "NULL", "true", "true"},
+		{"NULL", "NULL", "false", "true"},
+	})
+
+	res := mustExecute(t, mb, "select null from users;")
+	expectColumns(t, res, []string{"?column?"}, []ColumnType{NullType})
+}
+
+func TestNullErrors(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table users (name text, age int);")
+
+	expectError(t, mb, "select name from users where age is 5;", nil)
+	expectError(t, mb, "select name from users where age is not;", nil)
+	expectError(t, mb, "select name from users where null and age;", ErrTypeMismatch)
+	expectError(t, mb, "insert into users values (null);", ErrMissingValues)
+}
+
+func TestBlobs(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table f (name text, data bytea);")
+	mustExecute(t, mb, "insert into f values ('a', X'DEADBEEF'), ('b', x''), ('c', null), ('d', cast('\\x0a0b' as blob));")
+
+	res := mustExecute(t, mb, "select data, length(data), data || X'00' from f;")
+	expectColumns(t, res, []string{"data", "length", "?column?"}, []ColumnType{BlobType, IntType, BlobType})
+	expectRows(t, mb, "select name, data, length(data), data || X'00' from f;", [][]string{
+		{"a", `\xdeadbeef`, "4", `\xdeadbeef00`},
+		{"b", `\x`, "0", `\x00`},
+		{"c", "NULL", "NULL", "NULL"},
+		{"d", `\x0a0b`, "2", `\x0a0b00`},
+	})
+
+	expectRows(t, mb, "select name from f where data = X'deadbeef';", [][]string{{"a"}})
+	expectRows(t, mb, "select name from f where data is not null order by data;", [][]string{{"b"}, {"d"}, {"a"}})
+	expectRows(t, mb, "select cast(X'0A' as text), cast('\\xff' as blob);", [][]string{{`\x0a`, `\xff`}})
+}
+
+func TestBlobErrors(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table f (data blob, n int);")
+
+	expectError(t, mb, "select X'GG';", ErrInvalidExpression)
+	expectError(t, mb, "select X'ABC';", ErrInvalidExpression)
+	expectError(t, mb, "select X'00' || 'x';", ErrTypeMismatch)
+	expectError(t, mb, "select X'01' + 1;", ErrTypeMismatch)
+	expectError(t, mb, "select upper(X'01');", ErrTypeMismatch)
+	expectError(t, mb, "insert into f (data) values (1);", ErrTypeMismatch)
+	expectError(t, mb, "insert into f (data) values ('\\x01');", ErrTypeMismatch)
+	expectError(t, mb, "insert into f (n) values (X'01');", ErrTypeMismatch)
+}
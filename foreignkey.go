@@ -0,0 +1,299 @@
+package memsql
+
+import (
+	"fmt"
+)
+
+// foreignKey is a ForeignKey resolved to column positions in the referencing table
+// and in the parent table it references
+type foreignKey struct {
+	columns       []int
+	parent        *Table
+	parentColumns []int
+	onDelete      ReferentialAction
+}
+
+func columnIndexes(table *Table, names []Token) ([]int, error) {
+	indexes := []int{}
+	for _, name := range names {
+		found := false
+		for i, col := range table.columns {
+			if col == name.value {
+				indexes = append(indexes, i)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("%w: %s.%s", ErrColumnDoesNotExists, table.name, name.value)
+		}
+	}
+
+	return indexes, nil
+}
+
+// compileForeignKey resolves fk for table, which may reference itself before it is
+// registered
+func (mb *MemoryBackend) compileForeignKey(table *Table, fk *ForeignKey) (*foreignKey, error) {
+	parent, ok := mb.tables[fk.Table.value]
+	if fk.Table.value == table.name {
+		parent, ok = table, true
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTableDoesNotExists, fk.Table.value)
+	}
+
+	columns, err := columnIndexes(table, fk.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	// without a column list the primary key is referenced
+	parentColumns := []int{}
+	if len(fk.References) > 0 {
+		parentColumns, err = columnIndexes(parent, fk.References)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for i, c := range parent.constraints {
+			if c.primaryKey {
+				parentColumns = append(parentColumns, i)
+			}
+		}
+
+		if len(parentColumns) == 0 {
+			return nil, fmt.Errorf("%w: %s has no primary key", ErrInvalidForeignKey, parent.name)
+		}
+	}
+
+	if len(columns) != len(parentColumns) {
+		return nil, fmt.Errorf("%w: %d columns can't reference %d columns", ErrInvalidForeignKey, len(columns), len(parentColumns))
+	}
+
+	// a key that contains a unique column is unique itself
+	unique := false
+	for i, c := range columns {
+		p := parentColumns[i]
+		if table.columnTypes[c] != parent.columnTypes[p] {
+			return nil, fmt.Errorf("%w: %s column %s can't reference %s column %s.%s", ErrInvalidForeignKey, table.columnTypes[c], table.columns[c], parent.columnTypes[p], parent.name, parent.columns[p])
+		}
+
+		unique = unique || parent.constraints[p].unique
+	}
+
+	if !unique {
+		return nil, fmt.Errorf("%w: referenced columns of %s must be unique", ErrInvalidForeignKey, parent.name)
+	}
+
+	return &foreignKey{
+		columns:       columns,
+		parent:        parent,
+		parentColumns: parentColumns,
+		onDelete:      fk.OnDelete,
+	}, nil
+}
+
+// referenceKey encodes the cells of row at columns, reporting false when one of
+// them is NULL since such a key doesn't reference anything
+func referenceKey(row []MemoryCell, columns []int) (string, bool) {
+	cells := make([]MemoryCell, len(columns))
+	for i, c := range columns {
+		if row[c].IsNull() {
+			return "", false
+		}
+
+		cells[i] = row[c]
+	}
+
+	return groupKey(cells), true
+}
+
+// replaceRows returns the rows of table once the rows at the positions in replaced
+// were removed and rows were added
+func replaceRows(table *Table, rows [][]MemoryCell, replaced map[int]bool) [][]MemoryCell {
+	result := [][]MemoryCell{}
+	for i, row := range table.rows {
+		if !replaced[i] {
+			result = append(result, row)
+		}
+	}
+
+	return append(result, rows...)
+}
+
+// checkReferences makes sure every key rows reference exists in its parent table,
+// rows take the place of the rows at the positions in replaced
+func (mb *MemoryBackend) checkReferences(table *Table, rows [][]MemoryCell, replaced map[int]bool) error {
+	for _, fk := range table.foreignKeys {
+		parentRows := fk.parent.rows
+		if fk.parent == table {
+			parentRows = replaceRows(table, rows, replaced)
+		}
+
+		keys := map[string]bool{}
+		for _, row := range parentRows {
+			if key, ok := referenceKey(row, fk.parentColumns); ok {
+				keys[key] = true
+			}
+		}
+
+		for _, row := range rows {
+			if key, ok := referenceKey(row, fk.columns); ok && !keys[key] {
+				return fmt.Errorf("%w: no matching row in %s", ErrForeignKeyViolation, fk.parent.name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkReferenced makes sure replacing rows of table doesn't remove keys that rows of
+// other tables still reference
+func (mb *MemoryBackend) checkReferenced(table *Table, rows [][]MemoryCell, replaced map[int]bool) error {
+	after := replaceRows(table, rows, replaced)
+
+	for _, child := range mb.sortedTables() {
+		for _, fk := range child.foreignKeys {
+			if fk.parent != table {
+				continue
+			}
+
+			keys := map[string]bool{}
+			for _, row := range after {
+				if key, ok := referenceKey(row, fk.parentColumns); ok {
+					keys[key] = true
+				}
+			}
+
+			childRows := child.rows
+			if child == table {
+				childRows = after
+			}
+
+			for _, row := range childRows {
+				if key, ok := referenceKey(row, fk.columns); ok && !keys[key] {
+					return fmt.Errorf("%w: %s rows still reference %s", ErrForeignKeyViolation, child.name, table.name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// deletion collects the rows a DELETE removes or changes in every table, so that
+// cascades are fully worked out before any table is touched
+type deletion struct {
+	deleted map[*Table]map[int]bool
+	updated map[*Table]map[int][]MemoryCell
+}
+
+// cascade applies the ON DELETE actions of the foreign keys referencing the rows of
+// table at the positions in removed
+func (mb *MemoryBackend) cascade(d *deletion, table *Table, removed []int) error {
+	for _, child := range mb.sortedTables() {
+		for _, fk := range child.foreignKeys {
+			if fk.parent != table {
+				continue
+			}
+
+			keys := map[string]bool{}
+			for _, i := range removed {
+				if key, ok := referenceKey(table.rows[i], fk.parentColumns); ok {
+					keys[key] = true
+				}
+			}
+
+			if len(keys) == 0 {
+				continue
+			}
+
+			if d.deleted[child] == nil {
+				d.deleted[child] = map[int]bool{}
+				d.updated[child] = map[int][]MemoryCell{}
+			}
+
+			cascaded := []int{}
+			for i, row := range child.rows {
+				if d.deleted[child][i] {
+					continue
+				}
+
+				if updated, ok := d.updated[child][i]; ok {
+					row = updated
+				}
+
+				key, ok := referenceKey(row, fk.columns)
+				if !ok || !keys[key] {
+					continue
+				}
+
+				switch fk.onDelete {
+				case CascadeAction:
+					d.deleted[child][i] = true
+					cascaded = append(cascaded, i)
+				case SetNullAction:
+					updated := append([]MemoryCell{}, row...)
+					for _, c := range fk.columns {
+						updated[c] = nil
+					}
+					d.updated[child][i] = updated
+				default:
+					return fmt.Errorf("%w: %s rows still reference %s", ErrForeignKeyViolation, child.name, table.name)
+				}
+			}
+
+			if len(cascaded) > 0 {
+				if err := mb.cascade(d, child, cascaded); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// apply checks the rows changed by d against their constraints, then removes and
+// replaces the rows of every table d touches
+func (mb *MemoryBackend) apply(d *deletion) error {
+	for _, table := range mb.sortedTables() {
+		rows := [][]MemoryCell{}
+		replaced := map[int]bool{}
+		for i := range table.rows {
+			if row, ok := d.updated[table][i]; ok && !d.deleted[table][i] {
+				rows = append(rows, row)
+				replaced[i] = true
+			}
+		}
+
+		if len(rows) == 0 {
+			continue
+		}
+
+		if err := mb.checkConstraints(table, rows, replaced); err != nil {
+			return err
+		}
+	}
+
+	for table, deleted := range d.deleted {
+		kept := [][]MemoryCell{}
+		for i, row := range table.rows {
+			if deleted[i] {
+				continue
+			}
+
+			if updated, ok := d.updated[table][i]; ok {
+				row = updated
+			}
+
+			kept = append(kept, row)
+		}
+
+		table.rows = kept
+	}
+
+	return nil
+}
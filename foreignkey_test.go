@@ -0,0 +1,88 @@
+package memsql
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDeleteCascade(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table authors (id int primary key, name text);")
+	mustExecute(t, mb, "create table books (id int primary key, author int references authors on delete cascade);")
+	mustExecute(t, mb, "create table pages (book int references books (id) on delete cascade, n int);")
+	mustExecute(t, mb, "insert into authors values (1, 'ann'), (2, 'bob');")
+	mustExecute(t, mb, "insert into books values (10, 1), (11, 1), (20, 2);")
+	mustExecute(t, mb, "insert into pages values (10, 1), (11, 1), (20, 1), (null, 1);")
+
+	mustExecute(t, mb, "delete from authors where id = 1;")
+
+	expectRows(t, mb, "select id from authors;", [][]string{{"2"}})
+	expectRows(t, mb, "select id from books;", [][]string{{"20"}})
+	expectRows(t, mb, "select book from pages;", [][]string{{"20"}, {"NULL"}})
+}
+
+func TestDeleteSetNull(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table teams (id int primary key);")
+	mustExecute(t, mb, "create table players (name text, team int references teams on delete set null);")
+	mustExecute(t, mb, "insert into teams values (1), (2);")
+	mustExecute(t, mb, "insert into players values ('ann', 1), ('bob', 2), ('cy', 1);")
+
+	mustExecute(t, mb, "delete from teams where id = 1;")
+
+	expectRows(t, mb, "select id from teams;", [][]string{{"2"}})
+	expectRows(t, mb, "select name, team from players;", [][]string{{"ann", "NULL"}, {"bob", "2"}, {"cy", "NULL"}})
+}
+
+func TestDeleteRestrict(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table parents (id int primary key);")
+	mustExecute(t, mb, "create table a (parent int references parents on delete restrict);")
+	mustExecute(t, mb, "create table b (parent int references parents);")
+	mustExecute(t, mb, "create table c (parent int references parents on delete cascade);")
+	mustExecute(t, mb, "insert into parents values (1), (2);")
+	mustExecute(t, mb, "insert into a values (1);")
+	mustExecute(t, mb, "insert into b values (1);")
+	mustExecute(t, mb, "insert into c values (1), (2);")
+
+	// a is always the first table the delete runs into
+	for i := 0; i < 10; i++ {
+		_, err := execute(mb, "delete from parents;")
+		if !errors.Is(err, ErrForeignKeyViolation) || !strings.Contains(err.Error(), "a rows") {
+			t.Fatalf("got %v, want a violation by a", err)
+		}
+	}
+
+	// nothing was deleted, not even the cascading rows
+	expectRows(t, mb, "select id from parents;", [][]string{{"1"}, {"2"}})
+	expectRows(t, mb, "select parent from c;", [][]string{{"1"}, {"2"}})
+
+	mustExecute(t, mb, "delete from parents where id = 2;")
+	expectRows(t, mb, "select id from parents;", [][]string{{"1"}})
+	expectRows(t, mb, "select parent from c;", [][]string{{"1"}})
+
+	if _, err := execute(mb, "insert into b values (3);"); !errors.Is(err, ErrForeignKeyViolation) {
+		t.Errorf("got %v, want %v", err, ErrForeignKeyViolation)
+	}
+}
+
+func TestSelfReference(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table nodes (id int primary key, parent int references nodes on delete cascade);")
+	mustExecute(t, mb, "insert into nodes values (1, null), (2, 1), (3, 2), (4, null), (5, 5);")
+
+	// a row may reference a row inserted along with it
+	mustExecute(t, mb, "insert into nodes values (6, 7), (7, 4);")
+
+	if _, err := execute(mb, "insert into nodes values (8, 9);"); !errors.Is(err, ErrForeignKeyViolation) {
+		t.Errorf("got %v, want %v", err, ErrForeignKeyViolation)
+	}
+
+	mustExecute(t, mb, "delete from nodes where id = 1;")
+	expectRows(t, mb, "select id from nodes;", [][]string{{"4"}, {"5"}, {"6"}, {"7"}})
+
+	mustExecute(t, mb, "delete from nodes where id = 5;")
+	mustExecute(t, mb, "delete from nodes where id = 4;")
+	expectRows(t, mb, "select id from nodes;", [][]string{})
+}
@@ -0,0 +1,496 @@
+package memsql
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// signature is one combination of argument types a function takes and the type it
+// returns for them
+type signature struct {
+	args    []ColumnType
+	returns ColumnType
+}
+
+func (s signature) String() string {
+	args := []string{}
+	for _, arg := range s.args {
+		args = append(args, arg.String())
+	}
+
+	return "(" + strings.Join(args, ", ") + ")"
+}
+
+// scalarFunction computes one cell from the cells of its arguments. Most functions
+// declare their signatures, arguments are converted to the types of the first one
+// they fit when a call is compiled. Functions whose arguments can't be listed
+// work out their result type with returns instead, rejecting arguments they can't
+// take. Calls return NULL when any argument is NULL unless acceptsNull is set
+type scalarFunction struct {
+	signatures  []signature
+	returns     func(args []ColumnType) (ColumnType, error)
+	acceptsNull bool
+	evaluate    func(args []MemoryCell, types []ColumnType) (MemoryCell, error)
+}
+
+// fitsSignature reports whether an argument of type typ can be passed where want
+// is declared, numbers are only widened and dates only become timestamps
+func fitsSignature(typ, want ColumnType) bool {
+	common, ok := commonType(typ, want)
+	return ok && common == want
+}
+
+// resolve picks the types the arguments of a call are converted to and the type it
+// returns
+func (fn *scalarFunction) resolve(name string, args []ColumnType) ([]ColumnType, ColumnType, error) {
+	if fn.signatures == nil {
+		typ, err := fn.returns(args)
+		return args, typ, err
+	}
+
+outer:
+	for _, sig := range fn.signatures {
+		if len(sig.args) != len(args) {
+			continue
+		}
+
+		for i, arg := range args {
+			if !fitsSignature(arg, sig.args[i]) {
+				continue outer
+			}
+		}
+
+		return sig.args, sig.returns, nil
+	}
+
+	expected := []string{}
+	for _, sig := range fn.signatures {
+		expected = append(expected, strings.ToUpper(name)+sig.String())
+	}
+
+	return nil, 0, fmt.Errorf("%w: %s%s doesn't match %s", ErrTypeMismatch, strings.ToUpper(name), signature{args: args}, strings.Join(expected, " or "))
+}
+
+func argumentCount(name string, args []ColumnType, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("%w: %s expects %d arguments, got %d", ErrInvalidExpression, strings.ToUpper(name), n, len(args))
+	}
+
+	return nil
+}
+
+// temporalAddition is the + or - of DATE_ADD and DATE_SUB, which take the same
+// operands as the operators
+func temporalAddition(name string, op Symbol) *scalarFunction {
+	return &scalarFunction{
+		returns: func(args []ColumnType) (ColumnType, error) {
+			if err := argumentCount(name, args, 2); err != nil {
+				return 0, err
+			}
+
+			to, _, ok := findTemporalOperation(op, args[0], args[1])
+			if !ok {
+				return 0, fmt.Errorf("%w: %s can't take %s and %s", ErrTypeMismatch, strings.ToUpper(name), args[0], args[1])
+			}
+
+			return to.typ, nil
+		},
+		evaluate: func(args []MemoryCell, types []ColumnType) (MemoryCell, error) {
+			to, swapped, _ := findTemporalOperation(op, types[0], types[1])
+			return to.apply(args[0], args[1], swapped)
+		},
+	}
+}
+
+// jsonDocument reads the json argument of a function, which may also be text
+// holding json
+func jsonDocument(cell MemoryCell, typ ColumnType) (MemoryCell, error) {
+	if typ == TextType {
+		return parseJSON(cell.AsText())
+	}
+
+	return cell, nil
+}
+
+// numericSignatures declares a function taking n numbers of the same type and
+// returning that type, for every numeric type
+func numericSignatures(n int) []signature {
+	signatures := []signature{}
+	for _, typ := range []ColumnType{IntType, BigIntType, DecimalType, FloatType} {
+		args := []ColumnType{}
+		for i := 0; i < n; i++ {
+			args = append(args, typ)
+		}
+
+		signatures = append(signatures, signature{args: args, returns: typ})
+	}
+
+	return signatures
+}
+
+// textFunction declares a function mapping one string to another
+func textFunction(f func(string) string) *scalarFunction {
+	return &scalarFunction{
+		signatures: []signature{{args: []ColumnType{TextType}, returns: TextType}},
+		evaluate: func(args []MemoryCell, _ []ColumnType) (MemoryCell, error) {
+			return MemoryCell(f(args[0].AsText())), nil
+		},
+	}
+}
+
+// substring returns count characters of s starting at the 1-based position start,
+// the characters before the start of s count towards count
+func substring(s string, start int64, count *int64) (string, error) {
+	runes := []rune(s)
+
+	end := int64(len(runes)) + 1
+	if count != nil {
+		if *count < 0 {
+			return "", fmt.Errorf("%w: negative substring length not allowed", ErrInvalidExpression)
+		}
+
+		end = min(end, start+*count)
+	}
+
+	start = max(start, 1)
+	if start >= end {
+		return "", nil
+	}
+
+	return string(runes[start-1 : end-1]), nil
+}
+
+// roundDecimal rounds r to places digits after the point, or to a multiple of a
+// power of ten when places is negative
+func roundDecimal(r *big.Rat, places int64) (*big.Rat, error) {
+	if places > decimalScale || places < -decimalScale {
+		return nil, fmt.Errorf("%w: ROUND can't keep %d digits", ErrNumericOutOfRange, places)
+	}
+
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(abs(places)), nil))
+	if places < 0 {
+		scale.Inv(scale)
+	}
+
+	rounded := new(big.Rat).SetInt(roundRat(new(big.Rat).Mul(r, scale)))
+	return rounded.Quo(rounded, scale), nil
+}
+
+func abs(i int64) int64 {
+	if i < 0 {
+		return -i
+	}
+
+	return i
+}
+
+var scalarFunctions = map[string]*scalarFunction{
+	"now": {
+		signatures: []signature{{returns: TimestampType}},
+		evaluate: func([]MemoryCell, []ColumnType) (MemoryCell, error) {
+			return timestampToCell(time.Now().UTC()), nil
+		},
+	},
+	"date_trunc": {
+		signatures: []signature{{args: []ColumnType{TextType, TimestampType}, returns: TimestampType}},
+		evaluate: func(args []MemoryCell, _ []ColumnType) (MemoryCell, error) {
+			t, err := truncateTime(args[1].AsTime(), args[0].AsText())
+			if err != nil {
+				return nil, err
+			}
+
+			return timestampToCell(t), nil
+		},
+	},
+	// EXTRACT(field FROM source) is parsed into a call with the field as text
+	"extract": {
+		signatures: []signature{
+			{args: []ColumnType{TextType, DateType}, returns: DecimalType},
+			{args: []ColumnType{TextType, TimeType}, returns: DecimalType},
+			{args: []ColumnType{TextType, TimestampType}, returns: DecimalType},
+			{args: []ColumnType{TextType, IntervalType}, returns: DecimalType},
+		},
+		evaluate: func(args []MemoryCell, types []ColumnType) (MemoryCell, error) {
+			r, err := extractField(args[0].AsText(), args[1], types[1])
+			if err != nil {
+				return nil, err
+			}
+
+			return decimalToCell(r), nil
+		},
+	},
+	"date_add": temporalAddition("date_add", plusSymbol),
+	"date_sub": temporalAddition("date_sub", minusSymbol),
+	"json_extract": {
+		signatures: []signature{
+			{args: []ColumnType{JsonType, TextType}, returns: JsonType},
+			{args: []ColumnType{TextType, TextType}, returns: JsonType},
+		},
+		evaluate: func(args []MemoryCell, types []ColumnType) (MemoryCell, error) {
+			doc, err := jsonDocument(args[0], types[0])
+			if err != nil {
+				return nil, err
+			}
+
+			v, ok, err := jsonExtract(doc, args[1].AsText())
+			if err != nil || !ok {
+				return nil, err
+			}
+
+			return MemoryCell(v), nil
+		},
+	},
+	// JSON_ARRAY_LENGTH is NULL when the path doesn't exist and fails for anything
+	// that isn't an array
+	"json_array_length": {
+		signatures: []signature{
+			{args: []ColumnType{JsonType}, returns: IntType},
+			{args: []ColumnType{TextType}, returns: IntType},
+			{args: []ColumnType{JsonType, TextType}, returns: IntType},
+			{args: []ColumnType{TextType, TextType}, returns: IntType},
+		},
+		evaluate: func(args []MemoryCell, types []ColumnType) (MemoryCell, error) {
+			doc, err := jsonDocument(args[0], types[0])
+			if err != nil {
+				return nil, err
+			}
+
+			path := "$"
+			if len(args) == 2 {
+				path = args[1].AsText()
+			}
+
+			v, ok, err := jsonExtract(doc, path)
+			if err != nil || !ok {
+				return nil, err
+			}
+
+			n, ok := jsonArrayLength(v)
+			if !ok {
+				return nil, fmt.Errorf("%w: json_array_length expects an array, got %s", ErrTypeMismatch, jsonKind(v))
+			}
+
+			return int32ToCell(int32(n)), nil
+		},
+	},
+	"upper": textFunction(strings.ToUpper),
+	"lower": textFunction(strings.ToLower),
+	// LENGTH counts the characters of a string or the bytes of a blob
+	"length": {
+		signatures: []signature{
+			{args: []ColumnType{TextType}, returns: IntType},
+			{args: []ColumnType{BlobType}, returns: IntType},
+		},
+		evaluate: func(args []MemoryCell, types []ColumnType) (MemoryCell, error) {
+			if types[0] == BlobType {
+				return int32ToCell(int32(len(args[0]))), nil
+			}
+
+			return int32ToCell(int32(utf8.RuneCount(args[0]))), nil
+		},
+	},
+	"substr": {
+		signatures: []signature{
+			{args: []ColumnType{TextType, IntType}, returns: TextType},
+			{args: []ColumnType{TextType, IntType, IntType}, returns: TextType},
+		},
+		evaluate: func(args []MemoryCell, _ []ColumnType) (MemoryCell, error) {
+			var count *int64
+			if len(args) == 3 {
+				n := args[2].AsInt64()
+				count = &n
+			}
+
+			s, err := substring(args[0].AsText(), args[1].AsInt64(), count)
+			if err != nil {
+				return nil, err
+			}
+
+			return MemoryCell(s), nil
+		},
+	},
+	// TRIM removes spaces, or the given characters, from both ends of a string
+	"trim": {
+		signatures: []signature{
+			{args: []ColumnType{TextType}, returns: TextType},
+			{args: []ColumnType{TextType, TextType}, returns: TextType},
+		},
+		evaluate: func(args []MemoryCell, _ []ColumnType) (MemoryCell, error) {
+			chars := " "
+			if len(args) == 2 {
+				chars = args[1].AsText()
+			}
+
+			return MemoryCell(strings.Trim(args[0].AsText(), chars)), nil
+		},
+	},
+	"replace": {
+		signatures: []signature{{args: []ColumnType{TextType, TextType, TextType}, returns: TextType}},
+		evaluate: func(args []MemoryCell, _ []ColumnType) (MemoryCell, error) {
+			s, from := args[0].AsText(), args[1].AsText()
+			if from == "" {
+				return args[0], nil
+			}
+
+			return MemoryCell(strings.ReplaceAll(s, from, args[2].AsText())), nil
+		},
+	},
+	// POSITION(substring IN string) is parsed into a call with the substring first,
+	// it's the 1-based position of the first match or 0 if there is none
+	"position": {
+		signatures: []signature{{args: []ColumnType{TextType, TextType}, returns: IntType}},
+		evaluate: func(args []MemoryCell, _ []ColumnType) (MemoryCell, error) {
+			s := args[1].AsText()
+			i := strings.Index(s, args[0].AsText())
+			if i < 0 {
+				return int32ToCell(0), nil
+			}
+
+			return int32ToCell(int32(utf8.RuneCountInString(s[:i]) + 1)), nil
+		},
+	},
+	// CONCAT joins the text of its arguments, whatever their types, skipping NULLs
+	"concat": {
+		returns: func(args []ColumnType) (ColumnType, error) {
+			if len(args) == 0 {
+				return 0, fmt.Errorf("%w: CONCAT expects at least one argument", ErrInvalidExpression)
+			}
+
+			return TextType, nil
+		},
+		acceptsNull: true,
+		evaluate: func(args []MemoryCell, types []ColumnType) (MemoryCell, error) {
+			var b strings.Builder
+			for i, arg := range args {
+				if !arg.IsNull() {
+					b.WriteString(FormatCell(arg, types[i]))
+				}
+			}
+
+			return MemoryCell(b.String()), nil
+		},
+	},
+	"abs": {
+		signatures: numericSignatures(1),
+		evaluate: func(args []MemoryCell, types []ColumnType) (MemoryCell, error) {
+			if types[0] == FloatType {
+				return float64ToCell(math.Abs(args[0].AsFloat64())), nil
+			}
+
+			r, err := cellToRat(args[0], types[0])
+			if err != nil {
+				return nil, err
+			}
+
+			return ratToCell(r.Abs(r), types[0])
+		},
+	},
+	// ROUND rounds halves away from zero, to a whole number or to the given number
+	// of digits after the point
+	"round": {
+		signatures: []signature{
+			{args: []ColumnType{DecimalType}, returns: DecimalType},
+			{args: []ColumnType{FloatType}, returns: FloatType},
+			{args: []ColumnType{DecimalType, IntType}, returns: DecimalType},
+		},
+		evaluate: func(args []MemoryCell, types []ColumnType) (MemoryCell, error) {
+			if types[0] == FloatType {
+				return float64ToCell(math.Round(args[0].AsFloat64())), nil
+			}
+
+			places := int64(0)
+			if len(args) == 2 {
+				places = args[1].AsInt64()
+			}
+
+			r, err := roundDecimal(args[0].AsDecimal(), places)
+			if err != nil {
+				return nil, err
+			}
+
+			return decimalToCell(r), nil
+		},
+	},
+	// MOD is the same as %
+	"mod": {
+		signatures: numericSignatures(2),
+		evaluate: func(args []MemoryCell, types []ColumnType) (MemoryCell, error) {
+			return arithmetic(percentSymbol, args[0], args[1], types[0])
+		},
+	},
+}
+
+// compileCall compiles a call to a scalar function, calls without arguments are
+// evaluated once per statement
+func (mb *MemoryBackend) compileCall(ce *CallExpression, cols []column) (*compiledExpression, error) {
+	name := ce.Name.value
+
+	// COALESCE and NULLIF take NULL arguments and don't always evaluate every one
+	switch name {
+	case "coalesce":
+		return mb.compileCoalesce(ce.Args, cols)
+	case "nullif":
+		return mb.compileNullif(ce.Args, cols)
+	}
+
+	fn, ok := scalarFunctions[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrFunctionDoesNotExists, name)
+	}
+
+	args := []*compiledExpression{}
+	types := []ColumnType{}
+	for _, exp := range ce.Args {
+		arg, err := mb.compileExpression(exp, cols)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, arg)
+		types = append(types, arg.typ)
+	}
+
+	types, typ, err := fn.resolve(name, types)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, arg := range args {
+		args[i] = coerce(arg, types[i])
+	}
+
+	if len(args) == 0 {
+		cell, err := fn.evaluate(nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return &compiledExpression{
+			typ: typ,
+			evaluate: func([]MemoryCell) (MemoryCell, error) {
+				return cell, nil
+			},
+		}, nil
+	}
+
+	return &compiledExpression{
+		typ: typ,
+		evaluate: func(row []MemoryCell) (MemoryCell, error) {
+			cells := make([]MemoryCell, len(args))
+			for i, arg := range args {
+				cell, err := arg.evaluate(row)
+				if err != nil || (cell.IsNull() && !fn.acceptsNull) {
+					return nil, err
+				}
+
+				cells[i] = cell
+			}
+
+			return fn.evaluate(cells, types)
+		},
+	}, nil
+}
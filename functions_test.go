@@ -0,0 +1,81 @@
+package memsql
+
+import "testing"
+
+func TestStringFunctions(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table p (id int, name text, note text);")
+	mustExecute(t, mb, "insert into p values (1, 'Ann Lee', '  hi  '), (2, 'Émile', 'xxhixx'), (3, null, null);")
+
+	expectRows(t, mb, "select upper(name), lower(name), length(name) from p;", [][]string{
+		{"ANN LEE", "ann lee", "7"},
+		{"ÉMILE", "émile", "5"},
+		{"NULL", "NULL", "NULL"},
+	})
+	expectRows(t, mb, "select substr(name, 2), substr(name, 1, 3), substr(name, 0, 2), substr(name, 10) from p where id < 3;", [][]string{
+		{"nn Lee", "Ann", "A", ""},
+		{"mile", "Émi", "É", ""},
+	})
+	expectRows(t, mb, "select '[' || trim(note) || ']', trim(note, 'x '), replace(name, 'e', '3'), replace(name, '', '3') from p where id < 3;", [][]string{
+		{"[hi]", "hi", "Ann L33", "Ann Lee"},
+		{"[xxhixx]", "hi", "Émil3", "Émile"},
+	})
+	expectRows(t, mb, "select position('Lee' in name), position('m' in name), position('z' in name), position('' in name) from p where id < 3;", [][]string{
+		{"5", "0", "0", "1"},
+		{"0", "2", "0", "1"},
+	})
+	expectRows(t, mb, "select concat(name, '/', id, '/', note), concat(null, null) from p where id > 1;", [][]string{
+		{"Émile/2/xxhixx", ""},
+		{"/3/", ""},
+	})
+	expectRows(t, mb, "select id from p where lower(name) like 'ann%';", [][]string{{"1"}})
+}
+
+func TestMathFunctions(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table m (i int, b bigint, d decimal, f double precision);")
+	mustExecute(t, mb, "insert into m values (-7, -5000000000, -2.345, -2.5), (7, null, 2.5, 0.4);")
+
+	expectRows(t, mb, "select abs(i), abs(b), abs(d), abs(f) from m;", [][]string{
+		{"7", "5000000000", "2.345", "2.5"},
+		{"7", "NULL", "2.5", "0.4"},
+	})
+	expectRows(t, mb, "select round(d), round(d, 2), round(f), round(i) from m;", [][]string{
+		{"-2", "-2.35", "-3", "-7"},
+		{"3", "2.5", "0", "7"},
+	})
+	expectRows(t, mb, "select mod(i, 3), mod(d, 1), mod(b, 3) from m;", [][]string{
+		{"-1", "-0.345", "-2"},
+		{"1", "0.5", "NULL"},
+	})
+
+	res := mustExecute(t, mb, "select abs(i), round(i), mod(i, b), round(f) from m;")
+	expectColumns(t, res, []string{"abs", "round", "mod", "round"}, []ColumnType{IntType, DecimalType, BigIntType, FloatType})
+}
+
+func TestFunctionErrors(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table p (i int, s text, f double precision);")
+	mustExecute(t, mb, "insert into p values (-2147483648, 'abc', 1.5);")
+
+	expectError(t, mb, "select nosuch(s) from p;", ErrFunctionDoesNotExists)
+	expectError(t, mb, "select sum2(i) from p;", ErrFunctionDoesNotExists)
+
+	expectError(t, mb, "select upper() from p;", ErrTypeMismatch)
+	expectError(t, mb, "select upper(s, s) from p;", ErrTypeMismatch)
+	expectError(t, mb, "select substr(s) from p;", ErrTypeMismatch)
+	expectError(t, mb, "select replace(s, 'a') from p;", ErrTypeMismatch)
+	expectError(t, mb, "select mod(i) from p;", ErrTypeMismatch)
+	expectError(t, mb, "select concat() from p;", ErrInvalidExpression)
+
+	expectError(t, mb, "select upper(i) from p;", ErrTypeMismatch)
+	expectError(t, mb, "select length(f) from p;", ErrTypeMismatch)
+	expectError(t, mb, "select substr(s, 'a') from p;", ErrTypeMismatch)
+	expectError(t, mb, "select abs(s) from p;", ErrTypeMismatch)
+	expectError(t, mb, "select round(f, 2) from p;", ErrTypeMismatch)
+	expectError(t, mb, "select position(1 in s) from p;", ErrTypeMismatch)
+
+	expectError(t, mb, "select abs(i) from p;", ErrIntegerOutOfRange)
+	expectError(t, mb, "select mod(i, 0) from p;", ErrDivisionByZero)
+	expectError(t, mb, "select substr(s, 1, -1) from p;", ErrInvalidExpression)
+}
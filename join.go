@@ -0,0 +1,187 @@
+package memsql
+
+// scanTableReference returns the columns and rows a FROM clause produces, a missing
+// FROM clause produces a single row without columns
+func (mb *MemoryBackend) scanTableReference(ref *TableReference) ([]column, [][]MemoryCell, error) {
+	if ref == nil {
+		return []column{}, [][]MemoryCell{{}}, nil
+	}
+
+	if ref.Join != nil {
+		return mb.join(ref.Join)
+	}
+
+	table, ok := mb.tables[ref.Table.value]
+	if !ok {
+		return nil, nil, ErrTableDoesNotExists
+	}
+
+	cols := table.schema()
+	if ref.As != nil {
+		for i := range cols {
+			cols[i].table = ref.As.value
+		}
+	}
+
+	return cols, table.rows, nil
+}
+
+// equiJoinKeys picks the col = col comparisons out of the AND-ed terms of an ON
+// condition where one side only reads the left rows and the other only the right rows
+func (mb *MemoryBackend) equiJoinKeys(on *Expression, left, right []column) ([]*compiledExpression, []*compiledExpression) {
+	terms := []*Expression{on}
+	leftKeys := []*compiledExpression{}
+	rightKeys := []*compiledExpression{}
+
+	for len(terms) > 0 {
+		term := terms[0]
+		terms = terms[1:]
+
+		if term == nil || term.Kind != BinaryKind {
+			continue
+		}
+
+		op := term.Binary.Op
+		if op.kind == keywordKind && Keyword(op.value) == andKeyword {
+			terms = append(terms, term.Binary.A, term.Binary.B)
+			continue
+		}
+
+		if op.kind != symbolKind || Symbol(op.value) != eqSymbol {
+			continue
+		}
+
+		// an expression that compiles against one side's columns only reads that side
+		for _, sides := range [][2]*Expression{{term.Binary.A, term.Binary.B}, {term.Binary.B, term.Binary.A}} {
+			l, lerr := mb.compileExpression(sides[0], left)
+			r, rerr := mb.compileExpression(sides[1], right)
+			if lerr == nil && rerr == nil && l.typ == r.typ {
+				leftKeys = append(leftKeys, l)
+				rightKeys = append(rightKeys, r)
+				break
+			}
+		}
+	}
+
+	return leftKeys, rightKeys
+}
+
+// evaluateJoinKey evaluates the key cells of a row, reporting false when a cell is
+// NULL since that is never equal to anything
+func evaluateJoinKey(keys []*compiledExpression, row []MemoryCell) (string, bool, error) {
+	cells := make([]MemoryCell, len(keys))
+	for i, key := range keys {
+		cell, err := key.evaluate(row)
+		if err != nil {
+			return "", false, err
+		}
+
+		if cell.IsNull() {
+			return "", false, nil
+		}
+
+		cells[i] = cell
+	}
+
+	return groupKey(cells), true, nil
+}
+
+func nullRow(n int) []MemoryCell {
+	return make([]MemoryCell, n)
+}
+
+// join pairs up the rows of both sides of j, using a hash join when the ON condition
+// has equality keys and a nested loop join otherwise, outer joins pad the rows that
+// found no match with NULLs
+func (mb *MemoryBackend) join(j *Join) ([]column, [][]MemoryCell, error) {
+	leftCols, leftRows, err := mb.scanTableReference(j.Left)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rightCols, rightRows, err := mb.scanTableReference(j.Right)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cols := append(append([]column{}, leftCols...), rightCols...)
+
+	on, err := mb.compilePredicate(j.On, cols)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// by default every right row is a candidate for every left row
+	allRight := make([]int, len(rightRows))
+	for i := range allRight {
+		allRight[i] = i
+	}
+	candidates := func([]MemoryCell) ([]int, error) {
+		return allRight, nil
+	}
+
+	leftKeys, rightKeys := mb.equiJoinKeys(j.On, leftCols, rightCols)
+	if len(leftKeys) > 0 {
+		// build a hash table over the right rows, then probe it with each left row
+		index := map[string][]int{}
+		for i, row := range rightRows {
+			key, ok, err := evaluateJoinKey(rightKeys, row)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if ok {
+				index[key] = append(index[key], i)
+			}
+		}
+
+		candidates = func(row []MemoryCell) ([]int, error) {
+			key, ok, err := evaluateJoinKey(leftKeys, row)
+			if err != nil || !ok {
+				return nil, err
+			}
+
+			return index[key], nil
+		}
+	}
+
+	rows := [][]MemoryCell{}
+	rightMatched := make([]bool, len(rightRows))
+	for _, l := range leftRows {
+		matches, err := candidates(l)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		matched := false
+		for _, i := range matches {
+			row := append(append([]MemoryCell{}, l...), rightRows[i]...)
+
+			// the hash only narrows the candidates, the whole ON condition still decides
+			ok, err := on.test(row)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if ok {
+				rows = append(rows, row)
+				matched = true
+				rightMatched[i] = true
+			}
+		}
+
+		if !matched && j.Type == LeftJoin {
+			rows = append(rows, append(append([]MemoryCell{}, l...), nullRow(len(rightCols))...))
+		}
+	}
+
+	if j.Type == RightJoin {
+		for i, r := range rightRows {
+			if !rightMatched[i] {
+				rows = append(rows, append(nullRow(len(leftCols)), r...))
+			}
+		}
+	}
+
+	return cols, rows, nil
+}
@@ -0,0 +1,82 @@
+package memsql
+
+import (
+	"testing"
+)
+
+func joinFixture(t *testing.T) *MemoryBackend {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table users (id int, name text);")
+	mustExecute(t, mb, "create table orders (id int, user_id int, total int);")
+	mustExecute(t, mb, "insert into users values (1, 'ann'), (2, 'bob'), (3, 'cy');")
+	mustExecute(t, mb, "insert into orders values (10, 1, 5), (11, 1, 7), (12, 2, 3), (13, 4, 9);")
+
+	return mb
+}
+
+func TestJoins(t *testing.T) {
+	mb := joinFixture(t)
+
+	expectRows(t, mb, "select u.name, o.total from users u inner join orders o on u.id = o.user_id;",
+		[][]string{{"ann", "5"}, {"ann", "7"}, {"bob", "3"}})
+	expectRows(t, mb, "select name, total from users join orders on users.id = user_id where total > 4;",
+		[][]string{{"ann", "5"}, {"ann", "7"}})
+	expectRows(t, mb, "select u.name, o.id from users u left join orders o on u.id = o.user_id;",
+		[][]string{{"ann", "10"}, {"ann", "11"}, {"bob", "12"}, {"cy", "NULL"}})
+	expectRows(t, mb, "select u.name, o.id from users u left outer join orders o on u.id = o.user_id and o.total > 5;",
+		[][]string{{"ann", "11"}, {"bob", "NULL"}, {"cy", "NULL"}})
+	expectRows(t, mb, "select u.name, o.id from users u right join orders o on u.id = o.user_id;",
+		[][]string{{"ann", "10"}, {"ann", "11"}, {"bob", "12"}, {"NULL", "13"}})
+	expectRows(t, mb, "select u.id, o.id from users u cross join orders o where o.id = 10;",
+		[][]string{{"1", "10"}, {"2", "10"}, {"3", "10"}})
+	expectRows(t, mb, "select u.id, o.id from users u, orders o where u.id = o.user_id and u.id = 2;",
+		[][]string{{"2", "12"}})
+
+	// a table joined with itself needs aliases to tell the two apart
+	expectRows(t, mb, "select a.name, b.name from users a join users b on a.id + 1 = b.id;",
+		[][]string{{"ann", "bob"}, {"bob", "cy"}})
+
+	// joins chain from left to right
+	mustExecute(t, mb, "create table items (order_id int, sku text);")
+	mustExecute(t, mb, "insert into items values (10, 'x'), (12, 'y');")
+	expectRows(t, mb, "select u.name, i.sku from users u join orders o on u.id = o.user_id join items i on i.order_id = o.id;",
+		[][]string{{"ann", "x"}, {"bob", "y"}})
+}
+
+func TestJoinErrors(t *testing.T) {
+	mb := joinFixture(t)
+
+	expectError(t, mb, "select id from users join orders on users.id = orders.user_id;", ErrColumnAmbiguous)
+	expectError(t, mb, "select u.id from users join orders on users.id = orders.user_id;", ErrColumnDoesNotExists)
+	expectError(t, mb, "select users.id from users join missing on users.id = missing.id;", ErrTableDoesNotExists)
+	expectError(t, mb, "select users.id from users join orders on users.name;", ErrTypeMismatch)
+	expectError(t, mb, "select users.id from users join orders on users.name = orders.id;", ErrTypeMismatch)
+	expectError(t, mb, "select users.id from users join orders;", nil)
+}
+
+func TestGroupByOverJoin(t *testing.T) {
+	mb := joinFixture(t)
+
+	expectRows(t, mb, "select u.name, count(*), sum(total) from users u join orders o on u.id = o.user_id group by name order by name;",
+		[][]string{{"ann", "2", "12"}, {"bob", "1", "3"}})
+	expectRows(t, mb, "select name, count(o.id) from users u left join orders o on u.id = user_id group by u.name having count(o.id) < 2 order by u.name;",
+		[][]string{{"bob", "1"}, {"cy", "0"}})
+
+	expectError(t, mb, "select u.id, count(*) from users u join orders o on u.id = o.user_id group by name;", ErrColumnNotGrouped)
+	expectError(t, mb, "select id, count(*) from users u join orders o on u.id = o.user_id group by u.id;", ErrColumnAmbiguous)
+}
+
+func TestLeftAndRightAsColumnNames(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table edges (left int, right int);")
+	mustExecute(t, mb, "create table nodes (id int);")
+	mustExecute(t, mb, "insert into edges (right, left) values (2, 1), (3, 2);")
+	mustExecute(t, mb, "insert into nodes values (1), (2);")
+
+	expectRows(t, mb, "select left, edges.right from edges where right > 2;", [][]string{{"2", "3"}})
+	expectRows(t, mb, "select e.left, n.id from edges e left join nodes n on e.right = n.id;", [][]string{{"1", "2"}, {"2", "NULL"}})
+	expectRows(t, mb, "select n.id, right from nodes n right join edges on left = n.id order by right;", [][]string{{"1", "2"}, {"2", "3"}})
+
+	mustExecute(t, mb, "update edges set left = right + 1 where left = 1;")
+	expectRows(t, mb, "select left as right from edges order by left;", [][]string{{"2"}, {"3"}})
+}
@@ -0,0 +1,240 @@
+package memsql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseJSON validates s and stores it without whitespace, the keys of objects
+// keep the order they were written in
+func parseJSON(s string) (MemoryCell, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(s)); err != nil {
+		return nil, fmt.Errorf("%w: invalid json: %s", ErrInvalidExpression, err)
+	}
+
+	return MemoryCell(buf.Bytes()), nil
+}
+
+// jsonToText is the text ->> returns, strings lose their quotes and a json null
+// is NULL
+func jsonToText(v json.RawMessage) MemoryCell {
+	switch v[0] {
+	case 'n':
+		return nil
+	case '"':
+		// v is a slice of a document that was validated when it was stored
+		var s string
+		_ = json.Unmarshal(v, &s)
+		return MemoryCell(s)
+	}
+
+	return MemoryCell(v)
+}
+
+// jsonField looks up the member key of an object or, when key is a number, the
+// element at that index of an array counting from the end when it's negative.
+// The value is a slice of v, so it keeps the order of its keys
+func jsonField(v json.RawMessage, key string, index bool) (json.RawMessage, bool) {
+	switch v[0] {
+	case '{':
+		if index {
+			return nil, false
+		}
+
+		var members map[string]json.RawMessage
+		_ = json.Unmarshal(v, &members)
+
+		field, ok := members[key]
+		return field, ok
+	case '[':
+		if !index {
+			return nil, false
+		}
+
+		i, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, false
+		}
+
+		var elements []json.RawMessage
+		_ = json.Unmarshal(v, &elements)
+
+		if i < 0 {
+			i += len(elements)
+		}
+
+		if i < 0 || i >= len(elements) {
+			return nil, false
+		}
+
+		return elements[i], true
+	}
+
+	return nil, false
+}
+
+// jsonArrayLength counts the elements of v, reporting false when it isn't an array
+func jsonArrayLength(v json.RawMessage) (int, bool) {
+	if v[0] != '[' {
+		return 0, false
+	}
+
+	var elements []json.RawMessage
+	_ = json.Unmarshal(v, &elements)
+
+	return len(elements), true
+}
+
+// jsonKind names the kind of json value v is, for error messages
+func jsonKind(v json.RawMessage) string {
+	switch v[0] {
+	case '{':
+		return "an object"
+	case '[':
+		return "an array"
+	case '"':
+		return "a string"
+	case 't', 'f':
+		return "a boolean"
+	case 'n':
+		return "null"
+	}
+
+	return "a number"
+}
+
+// jsonStep is one member name or array index of a path
+type jsonStep struct {
+	key   string
+	index bool
+}
+
+// parseJSONPath parses paths such as $.a.b[0], members can be quoted as in
+// $."a b" and array indexes can be negative
+func parseJSONPath(path string) ([]jsonStep, error) {
+	invalid := fmt.Errorf("%w: invalid json path '%s'", ErrInvalidExpression, path)
+
+	if !strings.HasPrefix(path, "$") {
+		return nil, invalid
+	}
+
+	steps := []jsonStep{}
+	rest := path[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+
+			if strings.HasPrefix(rest, `"`) {
+				end := strings.Index(rest[1:], `"`)
+				if end < 0 {
+					return nil, invalid
+				}
+
+				steps = append(steps, jsonStep{key: rest[1 : end+1]})
+				rest = rest[end+2:]
+				continue
+			}
+
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+
+			if end == 0 {
+				return nil, invalid
+			}
+
+			steps = append(steps, jsonStep{key: rest[:end]})
+			rest = rest[end:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, invalid
+			}
+
+			if _, err := strconv.Atoi(rest[1:end]); err != nil {
+				return nil, invalid
+			}
+
+			steps = append(steps, jsonStep{key: rest[1:end], index: true})
+			rest = rest[end+1:]
+		default:
+			return nil, invalid
+		}
+	}
+
+	return steps, nil
+}
+
+// jsonExtract follows path through the document in cell, reporting false when
+// some step of it doesn't exist
+func jsonExtract(cell MemoryCell, path string) (json.RawMessage, bool, error) {
+	steps, err := parseJSONPath(path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	v := json.RawMessage(cell)
+	for _, step := range steps {
+		var ok bool
+		if v, ok = jsonField(v, step.key, step.index); !ok {
+			return nil, false, nil
+		}
+	}
+
+	return v, true, nil
+}
+
+// compileJSONOperator compiles -> or ->>, which take a member name or an array
+// index on their right
+func compileJSONOperator(op Symbol, a, b *compiledExpression) (*compiledExpression, error) {
+	if !fitsType(a.typ, JsonType) {
+		return nil, fmt.Errorf("%w: %s expects json on the left, got %s", ErrTypeMismatch, op, a.typ)
+	}
+
+	index := b.typ == IntType || b.typ == BigIntType
+	if !index && !fitsType(b.typ, TextType) {
+		return nil, fmt.Errorf("%w: %s expects a key or an index on the right, got %s", ErrTypeMismatch, op, b.typ)
+	}
+
+	typ := JsonType
+	if op == longArrowSymbol {
+		typ = TextType
+	}
+
+	return &compiledExpression{
+		typ: typ,
+		evaluate: func(row []MemoryCell) (MemoryCell, error) {
+			l, err := a.evaluate(row)
+			if err != nil {
+				return nil, err
+			}
+
+			r, err := b.evaluate(row)
+			if err != nil || l.IsNull() || r.IsNull() {
+				return nil, err
+			}
+
+			key := r.AsText()
+			if index {
+				key = strconv.FormatInt(r.AsInt64(), 10)
+			}
+
+			v, ok := jsonField(json.RawMessage(l), key, index)
+			if !ok {
+				return nil, nil
+			}
+
+			if typ == TextType {
+				return jsonToText(v), nil
+			}
+
+			return MemoryCell(v), nil
+		},
+	}, nil
+}
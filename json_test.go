@@ -0,0 +1,69 @@
+package memsql
+
+import "testing"
+
+func TestJSONKeepsKeyOrder(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table docs (id int, body json);")
+	mustExecute(t, mb, `insert into docs values (1, '{ "z": 1, "a": {"y": [3, {"c": 1, "b": 2}], "x": null}, "m": "<&>" }');`)
+
+	expectRows(t, mb, "select body from docs;", [][]string{{`{"z":1,"a":{"y":[3,{"c":1,"b":2}],"x":null},"m":"<&>"}`}})
+	expectRows(t, mb, "select body -> 'a', body -> 'a' -> 'y' -> 1, json_extract(body, '$.a.y') from docs;", [][]string{
+		{`{"y":[3,{"c":1,"b":2}],"x":null}`, `{"c":1,"b":2}`, `[3,{"c":1,"b":2}]`},
+	})
+
+	// a document read back can be stored again unchanged
+	mustExecute(t, mb, "insert into docs select 2, body from docs;")
+	mustExecute(t, mb, "insert into docs select 3, cast(cast(body as text) as json) from docs where id = 1;")
+	expectRows(t, mb, `select id from docs where body = json '{"z": 1, "a": {"y": [3, {"c": 1, "b": 2}], "x": null}, "m": "<&>"}' order by id;`, [][]string{{"1"}, {"2"}, {"3"}})
+}
+
+func TestJSONOperators(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table events (id int, payload json);")
+	mustExecute(t, mb, `insert into events values
+		(1, '{"user": {"name": "ann", "tags": ["a", "b", "c"]}, "n": 5, "ok": true}'),
+		(2, '{"user": {"name": "bob", "tags": []}, "n": null}'),
+		(3, '[10, 20, 30]'),
+		(4, null);`)
+
+	expectRows(t, mb, "select id, payload -> 'user' -> 'name', payload -> 'user' ->> 'name', payload ->> 'n' from events;", [][]string{
+		{"1", `"ann"`, "ann", "5"},
+		{"2", `"bob"`, "bob", "NULL"},
+		{"3", "NULL", "NULL", "NULL"},
+		{"4", "NULL", "NULL", "NULL"},
+	})
+	expectRows(t, mb, "select payload -> 0, payload ->> -1, payload -> 3, payload -> '0' from events where id = 3;", [][]string{{"10", "30", "NULL", "NULL"}})
+	expectRows(t, mb, "select id from events where payload -> 'user' ->> 'name' = 'bob';", [][]string{{"2"}})
+	expectRows(t, mb, "select cast(payload -> 'n' as int) + 1, cast(payload ->> 'ok' as boolean) from events where id = 1;", [][]string{{"6", "true"}})
+
+	expectRows(t, mb, `select json_extract(payload, '$.user.tags[1]'), json_extract(payload, '$.user.tags[-1]'), json_extract(payload, '$.missing'), json_extract('{"a b": 1}', '$."a b"') from events where id = 1;`, [][]string{
+		{`"b"`, `"c"`, "NULL", "1"},
+	})
+	expectRows(t, mb, "select id, json_array_length(payload, '$.user.tags'), json_array_length(payload, '$.none') from events where id < 3;", [][]string{
+		{"1", "3", "NULL"},
+		{"2", "0", "NULL"},
+	})
+	expectRows(t, mb, "select json_array_length(payload) from events where id >= 3;", [][]string{{"3"}, {"NULL"}})
+}
+
+func TestJSONErrors(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table events (id int, payload json);")
+	mustExecute(t, mb, `insert into events values (1, '{"a": [1], "s": "x"}');`)
+
+	expectError(t, mb, "insert into events values (2, '{\"a\": }');", ErrInvalidExpression)
+	expectError(t, mb, "insert into events values (2, '[1] [2]');", ErrInvalidExpression)
+	expectError(t, mb, "insert into events values (2, '');", ErrInvalidExpression)
+	expectError(t, mb, "insert into events values (2, 1);", ErrTypeMismatch)
+	expectError(t, mb, "select json 'nope';", ErrInvalidExpression)
+	expectError(t, mb, "select json_extract(payload, 'a') from events;", ErrInvalidExpression)
+	expectError(t, mb, "select json_extract(payload, '$.a[x]') from events;", ErrInvalidExpression)
+	expectError(t, mb, "select id -> 'a' from events;", ErrTypeMismatch)
+	expectError(t, mb, "select payload -> true from events;", ErrTypeMismatch)
+
+	// only a path that isn't there is NULL, anything but an array is an error
+	expectError(t, mb, "select json_array_length(payload) from events;", ErrTypeMismatch)
+	expectError(t, mb, "select json_array_length(payload, '$.s') from events;", ErrTypeMismatch)
+	expectError(t, mb, "select json_array_length('null');", ErrTypeMismatch)
+}
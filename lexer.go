@@ -13,20 +13,96 @@ type Location struct {
 type Keyword string
 
 const (
-	createKeyword Keyword = "create"
-	selectKeyword Keyword = "select"
-	fromKeyword   Keyword = "from"
-	tableKeyword  Keyword = "table"
-	insertKeyword Keyword = "insert"
-	intoKeyword   Keyword = "into"
-	valuesKeyword Keyword = "values"
-	intKeyword    Keyword = "int"
-	textKeyword   Keyword = "text"
+	createKeyword     Keyword = "create"
+	selectKeyword     Keyword = "select"
+	fromKeyword       Keyword = "from"
+	tableKeyword      Keyword = "table"
+	insertKeyword     Keyword = "insert"
+	intoKeyword       Keyword = "into"
+	valuesKeyword     Keyword = "values"
+	intKeyword        Keyword = "int"
+	textKeyword       Keyword = "text"
+	whereKeyword      Keyword = "where"
+	andKeyword        Keyword = "and"
+	orKeyword         Keyword = "or"
+	notKeyword        Keyword = "not"
+	updateKeyword     Keyword = "update"
+	setKeyword        Keyword = "set"
+	deleteKeyword     Keyword = "delete"
+	asKeyword         Keyword = "as"
+	orderKeyword      Keyword = "order"
+	byKeyword         Keyword = "by"
+	ascKeyword        Keyword = "asc"
+	descKeyword       Keyword = "desc"
+	limitKeyword      Keyword = "limit"
+	offsetKeyword     Keyword = "offset"
+	groupKeyword      Keyword = "group"
+	havingKeyword     Keyword = "having"
+	joinKeyword       Keyword = "join"
+	innerKeyword      Keyword = "inner"
+	leftKeyword       Keyword = "left"
+	rightKeyword      Keyword = "right"
+	outerKeyword      Keyword = "outer"
+	crossKeyword      Keyword = "cross"
+	onKeyword         Keyword = "on"
+	nullKeyword       Keyword = "null"
+	isKeyword         Keyword = "is"
+	primaryKeyword    Keyword = "primary"
+	keyKeyword        Keyword = "key"
+	uniqueKeyword     Keyword = "unique"
+	defaultKeyword    Keyword = "default"
+	checkKeyword      Keyword = "check"
+	foreignKeyword    Keyword = "foreign"
+	referencesKeyword Keyword = "references"
+	cascadeKeyword    Keyword = "cascade"
+	restrictKeyword   Keyword = "restrict"
+	dropKeyword       Keyword = "drop"
+	truncateKeyword   Keyword = "truncate"
+	ifKeyword         Keyword = "if"
+	existsKeyword     Keyword = "exists"
+	alterKeyword      Keyword = "alter"
+	addKeyword        Keyword = "add"
+	columnKeyword     Keyword = "column"
+	renameKeyword     Keyword = "rename"
+	toKeyword         Keyword = "to"
+	trueKeyword       Keyword = "true"
+	falseKeyword      Keyword = "false"
+	booleanKeyword    Keyword = "boolean"
+	bigintKeyword     Keyword = "bigint"
+	realKeyword       Keyword = "real"
+	doubleKeyword     Keyword = "double"
+	precisionKeyword  Keyword = "precision"
+	decimalKeyword    Keyword = "decimal"
+	numericKeyword    Keyword = "numeric"
+	dateKeyword       Keyword = "date"
+	timeKeyword       Keyword = "time"
+	timestampKeyword  Keyword = "timestamp"
+	intervalKeyword   Keyword = "interval"
+	blobKeyword       Keyword = "blob"
+	byteaKeyword      Keyword = "bytea"
+	jsonKeyword       Keyword = "json"
+	likeKeyword       Keyword = "like"
+	ilikeKeyword      Keyword = "ilike"
+	escapeKeyword     Keyword = "escape"
+	inKeyword         Keyword = "in"
+	betweenKeyword    Keyword = "between"
+	caseKeyword       Keyword = "case"
+	whenKeyword       Keyword = "when"
+	thenKeyword       Keyword = "then"
+	elseKeyword       Keyword = "else"
+	endKeyword        Keyword = "end"
+	castKeyword       Keyword = "cast"
 )
 
 // create table <tablename> ;
 // insert into <tablename> (<columns>) values (<values>);
 // select * from <tablename>;
+// update <tablename> set <column> = <value> where <condition>;
+// delete from <tablename> where <condition>;
+// drop table [if exists] <tablename>;
+// truncate [table] <tablename>;
+// alter table <tablename> add [column] <column> <type> | drop [column] <column> |
+//     rename [column] <column> to <column> | rename to <tablename>;
 
 type Symbol string
 
@@ -36,6 +112,25 @@ const (
 	commaSymbol      Symbol = ","
 	leftParenSymbol  Symbol = "("
 	rightParenSymbol Symbol = ")"
+	eqSymbol         Symbol = "="
+	neqSymbol        Symbol = "<>"
+	ltSymbol         Symbol = "<"
+	lteSymbol        Symbol = "<="
+	gtSymbol         Symbol = ">"
+	gteSymbol        Symbol = ">="
+	dotSymbol        Symbol = "."
+	plusSymbol       Symbol = "+"
+	minusSymbol      Symbol = "-"
+	slashSymbol      Symbol = "/"
+	percentSymbol    Symbol = "%"
+	concatSymbol     Symbol = "||"
+	arrowSymbol      Symbol = "->"
+	longArrowSymbol  Symbol = "->>"
+	matchSymbol      Symbol = "~"
+	imatchSymbol     Symbol = "~*"
+	notMatchSymbol   Symbol = "!~"
+	notImatchSymbol  Symbol = "!~*"
+	castSymbol       Symbol = "::"
 )
 
 type TokenKind uint
@@ -46,6 +141,10 @@ const (
 	identifierKind
 	textKind
 	integerKind
+	// decimalKind is a number with a fractional part or an exponent
+	decimalKind
+	// blobKind is a hex string such as X'DEADBEEF', its value holds the hex digits
+	blobKind
 )
 
 type Token struct {
@@ -128,9 +227,14 @@ func lexNumber(source string, ic cursor) (*Token, cursor, bool) {
 		return nil, ic, false
 	}
 
+	kind := integerKind
+	if periodFound {
+		kind = decimalKind
+	}
+
 	return &Token{
 		value:    source[ic.pointer:cursor.pointer],
-		kind:     integerKind,
+		kind:     kind,
 		location: ic.location,
 	}, cursor, true
 }
@@ -182,6 +286,26 @@ func lexString(source string, ic cursor) (*Token, cursor, bool) {
 	return lexCharacterDelimited(source, ic, '\'')
 }
 
+// lexBlob lexes X'...', the digits are only checked to be hex when the literal is used
+func lexBlob(source string, ic cursor) (*Token, cursor, bool) {
+	if c := source[ic.pointer]; c != 'x' && c != 'X' {
+		return nil, ic, false
+	}
+
+	next := ic
+	next.pointer++
+	next.location.column++
+
+	t, cursor, ok := lexString(source, next)
+	if !ok {
+		return nil, ic, false
+	}
+
+	t.kind = blobKind
+	t.location = ic.location
+	return t, cursor, true
+}
+
 func lexSymbol(source string, ic cursor) (*Token, cursor, bool) {
 	c := source[ic.pointer]
 	cursor := ic
@@ -206,6 +330,25 @@ func lexSymbol(source string, ic cursor) (*Token, cursor, bool) {
 		asteriskSymbol,
 		leftParenSymbol,
 		rightParenSymbol,
+		eqSymbol,
+		neqSymbol,
+		ltSymbol,
+		lteSymbol,
+		gtSymbol,
+		gteSymbol,
+		dotSymbol,
+		plusSymbol,
+		minusSymbol,
+		slashSymbol,
+		percentSymbol,
+		concatSymbol,
+		arrowSymbol,
+		longArrowSymbol,
+		matchSymbol,
+		imatchSymbol,
+		notMatchSymbol,
+		notImatchSymbol,
+		castSymbol,
 	}
 
 	var options []string
@@ -220,6 +363,12 @@ func lexSymbol(source string, ic cursor) (*Token, cursor, bool) {
 		return nil, ic, false
 	}
 
+	// Leave numbers like .5 to lexNumber
+	next := ic.pointer + 1
+	if match == string(dotSymbol) && next < uint(len(source)) && source[next] >= '0' && source[next] <= '9' {
+		return nil, ic, false
+	}
+
 	cursor.pointer = ic.pointer + uint(len(match))
 	cursor.location.column = ic.location.column + uint(len(match))
 
@@ -242,6 +391,76 @@ func lexKeyword(source string, ic cursor) (*Token, cursor, bool) {
 		valuesKeyword,
 		intKeyword,
 		textKeyword,
+		whereKeyword,
+		andKeyword,
+		orKeyword,
+		notKeyword,
+		updateKeyword,
+		setKeyword,
+		deleteKeyword,
+		asKeyword,
+		orderKeyword,
+		byKeyword,
+		ascKeyword,
+		descKeyword,
+		limitKeyword,
+		offsetKeyword,
+		groupKeyword,
+		havingKeyword,
+		joinKeyword,
+		innerKeyword,
+		leftKeyword,
+		rightKeyword,
+		outerKeyword,
+		crossKeyword,
+		onKeyword,
+		nullKeyword,
+		isKeyword,
+		primaryKeyword,
+		keyKeyword,
+		uniqueKeyword,
+		defaultKeyword,
+		checkKeyword,
+		foreignKeyword,
+		referencesKeyword,
+		cascadeKeyword,
+		restrictKeyword,
+		dropKeyword,
+		truncateKeyword,
+		ifKeyword,
+		existsKeyword,
+		alterKeyword,
+		addKeyword,
+		columnKeyword,
+		renameKeyword,
+		toKeyword,
+		trueKeyword,
+		falseKeyword,
+		booleanKeyword,
+		bigintKeyword,
+		realKeyword,
+		doubleKeyword,
+		precisionKeyword,
+		decimalKeyword,
+		numericKeyword,
+		dateKeyword,
+		timeKeyword,
+		timestampKeyword,
+		intervalKeyword,
+		blobKeyword,
+		byteaKeyword,
+		jsonKeyword,
+		likeKeyword,
+		ilikeKeyword,
+		escapeKeyword,
+		inKeyword,
+		betweenKeyword,
+		caseKeyword,
+		whenKeyword,
+		thenKeyword,
+		elseKeyword,
+		endKeyword,
+		castKeyword,
 	}
 
 	var options []string
@@ -254,7 +473,13 @@ func lexKeyword(source string, ic cursor) (*Token, cursor, bool) {
 		return nil, ic, false
 	}
 
-	cursor.pointer = ic.pointer + uint(len(match))
+	// A keyword must end on a word boundary, otherwise "order_id" would lex as OR
+	end := ic.pointer + uint(len(match))
+	if end < uint(len(source)) && isIdentifierChar(source[end]) {
+		return nil, ic, false
+	}
+
+	cursor.pointer = end
 	cursor.location.column = ic.location.column + uint(len(match))
 
 	return &Token{
@@ -309,6 +534,12 @@ func longestMatch(source string, ic cursor, options []string) string {
 	return match
 }
 
+func isIdentifierChar(c byte) bool {
+	isAlpha := (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z')
+	isNumber := c >= '0' && c <= '9'
+	return isAlpha || isNumber || c == '_' || c == '$'
+}
+
 func lexIdentifier(source string, ic cursor) (*Token, cursor, bool) {
 	// handle separately if it is a double quotes
 	if token, newCursor, ok := lexCharacterDelimited(source, ic, '"'); ok {
@@ -334,9 +565,7 @@ func lexIdentifier(source string, ic cursor) (*Token, cursor, bool) {
 	for ; cursor.pointer < uint(len(source)); cursor.pointer++ {
 		c = source[cursor.pointer]
 
-		isAlpha := (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z')
-		isNumber := c >= '0' && c <= '9'
-		if isAlpha || isNumber || c == '_' || c == '$' {
+		if isIdentifierChar(c) {
 			value = append(value, c)
 			cursor.location.column++
 			continue
@@ -358,7 +587,7 @@ func lex(source string) ([]*Token, error) {
 
 lex:
 	for cursor.pointer < uint(len(source)) {
-		lexers := []lexer{lexKeyword, lexSymbol, lexString, lexNumber, lexIdentifier}
+		lexers := []lexer{lexKeyword, lexSymbol, lexString, lexBlob, lexNumber, lexIdentifier}
 
 		for _, l := range lexers {
 			if t, newCursor, ok := l(source, cursor); ok {
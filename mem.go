@@ -3,8 +3,14 @@ package memsql
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type MemoryCell []byte
@@ -18,16 +24,120 @@ func (mc MemoryCell) AsInt32() int32 {
 	return i
 }
 
+// AsInt64 reads an int or a bigint cell
+func (mc MemoryCell) AsInt64() int64 {
+	if len(mc) == 4 {
+		return int64(mc.AsInt32())
+	}
+
+	return int64(binary.BigEndian.Uint64(mc))
+}
+
+func (mc MemoryCell) AsFloat64() float64 {
+	return math.Float64frombits(binary.BigEndian.Uint64(mc))
+}
+
+// AsDecimal reads a decimal cell, which holds the shortest text that spells its value
+func (mc MemoryCell) AsDecimal() *big.Rat {
+	r, ok := new(big.Rat).SetString(string(mc))
+	if !ok {
+		panic("invalid decimal cell " + string(mc))
+	}
+
+	return r
+}
+
+// AsTime reads a date, time or timestamp cell, a time is read as that time on
+// 1970-01-01
+func (mc MemoryCell) AsTime() time.Time {
+	if len(mc) == 4 {
+		return time.Unix(int64(mc.AsInt32())*24*60*60, 0).UTC()
+	}
+
+	return time.UnixMicro(mc.AsInt64()).UTC()
+}
+
+func (mc MemoryCell) AsInterval() Interval {
+	return Interval{
+		Months:       mc[:4].AsInt32(),
+		Days:         mc[4:8].AsInt32(),
+		Microseconds: mc[8:].AsInt64(),
+	}
+}
+
+func (mc MemoryCell) AsBytes() []byte {
+	return mc
+}
+
+func (mc MemoryCell) AsJSON() any {
+	d := json.NewDecoder(bytes.NewReader(mc))
+	d.UseNumber()
+
+	// json cells are validated when they are stored
+	var v any
+	_ = d.Decode(&v)
+
+	return v
+}
+
 func (mc MemoryCell) AsText() string {
 	return string(mc)
 }
 
+// IsNull reports whether the cell is NULL
+func (mc MemoryCell) IsNull() bool {
+	return mc == nil
+}
+
+func (mc MemoryCell) AsBool() bool {
+	return len(mc) > 0 && mc[0] != 0
+}
+
+var (
+	trueMemoryCell  = MemoryCell{1}
+	falseMemoryCell = MemoryCell{0}
+)
+
+func boolToCell(b bool) MemoryCell {
+	if b {
+		return trueMemoryCell
+	}
+
+	return falseMemoryCell
+}
+
+// columnConstraints are the rules every value stored in a column has to follow, the
+// expressions are compiled whenever they're enforced so they can't go stale
+type columnConstraints struct {
+	notNull      bool
+	unique       bool
+	primaryKey   bool
+	defaultValue *Expression
+	check        *Expression
+}
+
 type Table struct {
+	name        string
 	columns     []string
 	columnTypes []ColumnType
+	constraints []columnConstraints
+	foreignKeys []*foreignKey
 	rows        [][]MemoryCell
 }
 
+func (t *Table) schema() []column {
+	cols := make([]column, len(t.columns))
+	for i, name := range t.columns {
+		cols[i] = column{
+			table: t.name,
+			name:  name,
+			typ:   t.columnTypes[i],
+		}
+	}
+
+	return cols
+}
+
 type MemoryBackend struct {
 	tables map[string]*Table
 }
@@ -38,53 +148,387 @@ func NewMemoryBackend() *MemoryBackend {
 	}
 }
 
+// sortedTables returns every table ordered by name, so that work spanning several
+// tables happens in the same order every time
+func (mb *MemoryBackend) sortedTables() []*Table {
+	names := []string{}
+	for name := range mb.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tables := []*Table{}
+	for _, name := range names {
+		tables = append(tables, mb.tables[name])
+	}
+
+	return tables
+}
+
 func (mb *MemoryBackend) CreateTable(cts *CreateTableStatement) error {
-	t := Table{}
-	mb.tables[cts.Name.value] = &t
+	if _, ok := mb.tables[cts.Name.value]; ok {
+		if cts.IfNotExists {
+			return nil
+		}
+
+		return fmt.Errorf("%w: %s", ErrTableAlreadyExists, cts.Name.value)
+	}
+
+	if cts.As != nil {
+		return mb.createTableAs(cts)
+	}
+
+	t := Table{
+		name: cts.Name.value,
+	}
 	if cts.Columns == nil {
 		return ErrMissingValues
 	}
 
 	for _, cols := range *cts.Columns {
-		t.columns = append(t.columns, cols.Name.value)
+		if err := t.addColumn(cols); err != nil {
+			return err
+		}
+	}
+
+	// make sure defaults and checks compile before the table can take any rows
+	schema := t.schema()
+	for i, c := range t.constraints {
+		if _, err := mb.compileDefault(&t, i); err != nil {
+			return err
+		}
+
+		if _, err := mb.compilePredicate(c.check, schema); err != nil {
+			return err
+		}
+	}
+
+	fks := cts.ForeignKeys
+	for _, cols := range *cts.Columns {
+		if cols.References != nil {
+			fks = append(fks, cols.References)
+		}
+	}
 
-		var dt ColumnType
-		switch cols.Datatype.value {
-		case "int":
-			dt = IntType
-		case "text":
-			dt = TextType
-		default:
-			return ErrInvalidDatatype
+	for _, fk := range fks {
+		compiled, err := mb.compileForeignKey(&t, fk)
+		if err != nil {
+			return err
 		}
 
-		t.columnTypes = append(t.columnTypes, dt)
+		t.foreignKeys = append(t.foreignKeys, compiled)
 	}
 
+	mb.tables[cts.Name.value] = &t
 	return nil
 }
 
-func (mb *MemoryBackend) tokenToCell(token *Token) MemoryCell {
-	if token.kind == integerKind {
-		buf := new(bytes.Buffer)
-		i, err := strconv.Atoi(token.value)
+// keywordTypes maps the type names columns and typed literals are declared with to
+// their types
+var keywordTypes = map[Keyword]ColumnType{
+	intKeyword:       IntType,
+	textKeyword:      TextType,
+	booleanKeyword:   BoolType,
+	bigintKeyword:    BigIntType,
+	realKeyword:      FloatType,
+	doubleKeyword:    FloatType,
+	decimalKeyword:   DecimalType,
+	numericKeyword:   DecimalType,
+	dateKeyword:      DateType,
+	timeKeyword:      TimeType,
+	timestampKeyword: TimestampType,
+	intervalKeyword:  IntervalType,
+	blobKeyword:      BlobType,
+	byteaKeyword:     BlobType,
+	jsonKeyword:      JsonType,
+}
+
+// addColumn appends the column cd declares to the schema of t
+func (t *Table) addColumn(cd *ColumnDefinition) error {
+	for _, name := range t.columns {
+		if name == cd.Name.value {
+			return fmt.Errorf("%w: %s", ErrColumnDuplicated, name)
+		}
+	}
+
+	dt, ok := keywordTypes[Keyword(cd.Datatype.value)]
+	if !ok {
+		return ErrInvalidDatatype
+	}
+
+	if cd.PrimaryKey {
+		for _, c := range t.constraints {
+			if c.primaryKey {
+				return ErrMultiplePrimaryKeys
+			}
+		}
+	}
+
+	t.columns = append(t.columns, cd.Name.value)
+	t.columnTypes = append(t.columnTypes, dt)
+
+	// a primary key is a unique column that can't be NULL
+	t.constraints = append(t.constraints, columnConstraints{
+		notNull:      cd.NotNull || cd.PrimaryKey,
+		unique:       cd.Unique || cd.PrimaryKey,
+		primaryKey:   cd.PrimaryKey,
+		defaultValue: cd.Default,
+		check:        cd.Check,
+	})
+
+	return nil
+}
+
+// createTableAs creates a table with the columns of the results of a query and fills
+// it with their rows
+func (mb *MemoryBackend) createTableAs(cts *CreateTableStatement) error {
+	results, err := mb.Select(cts.As)
+	if err != nil {
+		return err
+	}
+
+	t := Table{
+		name: cts.Name.value,
+	}
+
+	for _, col := range results.Columns {
+		for _, name := range t.columns {
+			if name == col.Name {
+				return fmt.Errorf("%w: %s", ErrColumnDuplicated, col.Name)
+			}
+		}
+
+		// a column of nothing but NULL literals stores text, like an untyped literal would
+		typ := col.Type
+		if typ == NullType {
+			typ = TextType
+		}
+
+		t.columns = append(t.columns, col.Name)
+		t.columnTypes = append(t.columnTypes, typ)
+		t.constraints = append(t.constraints, columnConstraints{})
+	}
+
+	for _, result := range results.Rows {
+		row := make([]MemoryCell, len(result))
+		for i, cell := range result {
+			row[i] = cell.(MemoryCell)
+		}
+
+		t.rows = append(t.rows, row)
+	}
+
+	mb.tables[cts.Name.value] = &t
+	return nil
+}
+
+// isDefault reports whether exp is the DEFAULT keyword standing in for a column's
+// default value
+func (exp *Expression) isDefault() bool {
+	return exp.Kind == LiteralKind && exp.Literal.kind == keywordKind && Keyword(exp.Literal.value) == defaultKeyword
+}
+
+// compileDefault compiles the default value of column i, which is NULL unless the
+// column declares one
+func (mb *MemoryBackend) compileDefault(table *Table, i int) (*compiledExpression, error) {
+	exp := table.constraints[i].defaultValue
+	if exp == nil {
+		exp = &Expression{
+			Literal: &Token{
+				value: string(nullKeyword),
+				kind:  keywordKind,
+			},
+			Kind: LiteralKind,
+		}
+	}
+
+	ce, err := mb.compileExpression(exp, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canAssign(ce.typ, table.columnTypes[i]) {
+		return nil, fmt.Errorf("%w: default of %s column %s can't be %s", ErrTypeMismatch, table.columnTypes[i], table.columns[i], ce.typ)
+	}
+
+	return coerce(ce, table.columnTypes[i]), nil
+}
+
+// compileColumnValue compiles a value being stored in column i of table, checking it
+// has the column's type, DEFAULT stands for the column's default value
+func (mb *MemoryBackend) compileColumnValue(table *Table, i int, exp *Expression, cols []column) (*compiledExpression, error) {
+	if exp.isDefault() {
+		return mb.compileDefault(table, i)
+	}
+
+	ce, err := mb.compileExpression(exp, cols)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canAssign(ce.typ, table.columnTypes[i]) {
+		return nil, fmt.Errorf("%w: cannot assign %s to %s column %s", ErrTypeMismatch, ce.typ, table.columnTypes[i], table.columns[i])
+	}
+
+	return coerce(ce, table.columnTypes[i]), nil
+}
+
+// checkConstraints makes sure rows can be stored in table next to its existing rows,
+// except the ones at the positions in replaced which the new rows take the place of
+func (mb *MemoryBackend) checkConstraints(table *Table, rows [][]MemoryCell, replaced map[int]bool) error {
+	schema := table.schema()
+
+	for i, c := range table.constraints {
+		check, err := mb.compilePredicate(c.check, schema)
 		if err != nil {
-			panic(err)
+			return err
+		}
+
+		for _, row := range rows {
+			if c.notNull && row[i].IsNull() {
+				return fmt.Errorf("%w: column %s", ErrNotNullViolation, table.columns[i])
+			}
+
+			// a check passes unless it is false, NULL doesn't violate it
+			if check != nil {
+				ok, err := check.evaluate(row)
+				if err != nil {
+					return err
+				}
+
+				if !ok.IsNull() && !ok.AsBool() {
+					return fmt.Errorf("%w: column %s", ErrCheckViolation, table.columns[i])
+				}
+			}
+		}
+
+		if !c.unique {
+			continue
+		}
+
+		// NULLs are never equal to each other, so they can repeat in a unique column
+		seen := map[string]bool{}
+		for j, row := range table.rows {
+			if !replaced[j] && !row[i].IsNull() {
+				seen[string(row[i])] = true
+			}
+		}
+
+		for _, row := range rows {
+			if row[i].IsNull() {
+				continue
+			}
+
+			if seen[string(row[i])] {
+				return fmt.Errorf("%w: column %s", ErrUniqueViolation, table.columns[i])
+			}
+			seen[string(row[i])] = true
+		}
+	}
+
+	return nil
+}
+
+func int32ToCell(i int32) MemoryCell {
+	buf := new(bytes.Buffer)
+	err := binary.Write(buf, binary.BigEndian, i)
+	if err != nil {
+		panic(err)
+	}
+
+	return MemoryCell(buf.Bytes())
+}
+
+func int64ToCell(i int64) MemoryCell {
+	return MemoryCell(binary.BigEndian.AppendUint64(nil, uint64(i)))
+}
+
+func float64ToCell(f float64) MemoryCell {
+	// -0 equals 0, so they have to be stored the same way
+	if f == 0 {
+		f = 0
+	}
+
+	return MemoryCell(binary.BigEndian.AppendUint64(nil, math.Float64bits(f)))
+}
+
+// decimalScale is how many digits after the point are kept of a decimal that can't
+// be written out exactly, such as 1 / 3
+const decimalScale = 16
+
+// decimalDigits is the number of digits after the point needed to write r exactly, or
+// decimalScale when that would take infinitely many
+func decimalDigits(r *big.Rat) int {
+	d := new(big.Int).Set(r.Denom())
+	twos := int(d.TrailingZeroBits())
+	d.Rsh(d, uint(twos))
+
+	fives := 0
+	five, m := big.NewInt(5), new(big.Int)
+	for {
+		q, rem := new(big.Int).DivMod(d, five, m)
+		if rem.Sign() != 0 {
+			break
 		}
 
-		err = binary.Write(buf, binary.BigEndian, int32(i))
+		d = q
+		fives++
+	}
+
+	if d.Cmp(big.NewInt(1)) != 0 {
+		return decimalScale
+	}
+
+	return max(twos, fives)
+}
+
+// decimalToCell stores the shortest text that spells r, so equal decimals are stored
+// the same way
+func decimalToCell(r *big.Rat) MemoryCell {
+	s := r.FloatString(decimalDigits(r))
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+
+	if s == "-0" {
+		s = "0"
+	}
+
+	return MemoryCell(s)
+}
+
+func (mb *MemoryBackend) tokenToCell(token *Token) (MemoryCell, error) {
+	if token.kind == integerKind {
+		i, err := strconv.ParseInt(token.value, 10, 64)
 		if err != nil {
-			panic(err)
+			return nil, fmt.Errorf("%w: %s is out of range for %s", ErrIntegerOutOfRange, token.value, BigIntType)
+		}
+
+		if i > math.MaxInt32 || i < math.MinInt32 {
+			return int64ToCell(i), nil
+		}
+
+		return int32ToCell(int32(i)), nil
+	}
+
+	if token.kind == decimalKind {
+		r, ok := new(big.Rat).SetString(token.value)
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid number %s", ErrInvalidExpression, token.value)
 		}
 
-		return MemoryCell(buf.Bytes())
+		return decimalToCell(r), nil
+	}
+
+	if token.kind == keywordKind && (Keyword(token.value) == trueKeyword || Keyword(token.value) == falseKeyword) {
+		return boolToCell(Keyword(token.value) == trueKeyword), nil
 	}
 
 	if token.kind == textKind {
-		return MemoryCell(token.value)
+		return MemoryCell(token.value), nil
 	}
 
-	return nil
+	return nil, nil
 }
 
 func (mb *MemoryBackend) Insert(is *InsertStatement) error {
@@ -93,94 +537,613 @@ func (mb *MemoryBackend) Insert(is *InsertStatement) error {
 		return ErrTableDoesNotExists
 	}
 
-	if is.Values == nil {
-		return nil
+	// without a column list the values go to every column in declaration order
+	indexes := make([]int, len(table.columns))
+	for i := range indexes {
+		indexes[i] = i
 	}
 
-	row := []MemoryCell{}
-	if len(*is.Values) != len(table.columns) {
-		return ErrMissingValues
+	if len(is.Columns) > 0 {
+		var err error
+		indexes, err = columnIndexes(table, is.Columns)
+		if err != nil {
+			return err
+		}
 	}
 
-	for _, value := range *is.Values {
-		if value.Kind != LiteralKind {
-			fmt.Println("Skipping non-literal")
+	listed := map[int]bool{}
+	for i, index := range indexes {
+		if listed[index] {
+			return fmt.Errorf("%w: %s", ErrColumnDuplicated, is.Columns[i].value)
+		}
+		listed[index] = true
+	}
+
+	// the columns that were left out take their defaults
+	defaults := map[int]*compiledExpression{}
+	for i := range table.columns {
+		if listed[i] {
 			continue
 		}
 
-		row = append(row, mb.tokenToCell(value.Literal))
+		ce, err := mb.compileDefault(table, i)
+		if err != nil {
+			return err
+		}
+		defaults[i] = ce
+	}
+
+	// work out the values of the listed columns of each row first
+	values := [][]MemoryCell{}
+	if is.Select != nil {
+		results, err := mb.Select(is.Select)
+		if err != nil {
+			return err
+		}
+
+		if len(results.Columns) != len(indexes) {
+			return ErrMissingValues
+		}
+
+		for i, col := range results.Columns {
+			if !canAssign(col.Type, table.columnTypes[indexes[i]]) {
+				return fmt.Errorf("%w: cannot assign %s to %s column %s", ErrTypeMismatch, col.Type, table.columnTypes[indexes[i]], table.columns[indexes[i]])
+			}
+		}
+
+		for _, result := range results.Rows {
+			row := make([]MemoryCell, len(result))
+			for i, cell := range result {
+				row[i], err = convertCell(cell.(MemoryCell), results.Columns[i].Type, table.columnTypes[indexes[i]])
+				if err != nil {
+					return err
+				}
+			}
+
+			values = append(values, row)
+		}
+	}
+
+	for _, tuple := range is.Values {
+		if len(*tuple) != len(indexes) {
+			return ErrMissingValues
+		}
+
+		row := make([]MemoryCell, len(indexes))
+		for i, value := range *tuple {
+			ce, err := mb.compileColumnValue(table, indexes[i], value, nil)
+			if err != nil {
+				return err
+			}
+
+			row[i], err = ce.evaluate(nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		values = append(values, row)
 	}
 
-	table.rows = append(table.rows, row)
+	rows := [][]MemoryCell{}
+	for _, value := range values {
+		row := make([]MemoryCell, len(table.columns))
+		for i, ce := range defaults {
+			cell, err := ce.evaluate(nil)
+			if err != nil {
+				return err
+			}
+			row[i] = cell
+		}
+
+		for i, cell := range value {
+			row[indexes[i]] = cell
+		}
+
+		rows = append(rows, row)
+	}
+
+	return mb.insertRows(table, rows)
+}
+
+// insertRows adds rows to table once all of them pass its constraints, so either
+// every row is inserted or none is
+func (mb *MemoryBackend) insertRows(table *Table, rows [][]MemoryCell) error {
+	if err := mb.checkConstraints(table, rows, nil); err != nil {
+		return err
+	}
+
+	if err := mb.checkReferences(table, rows, nil); err != nil {
+		return err
+	}
+
+	table.rows = append(table.rows, rows...)
 	return nil
 }
 
-func (mb *MemoryBackend) Select(ss *SelectStatement) (*Results, error) {
-	// get table from memory
-	table, ok := mb.tables[ss.From.value]
+func (mb *MemoryBackend) Update(us *UpdateStatement) (int, error) {
+	table, ok := mb.tables[us.Table.value]
 	if !ok {
-		return nil, ErrTableDoesNotExists
+		return 0, ErrTableDoesNotExists
 	}
 
-	results := [][]Cell{}
-	cols := []struct {
-		Type ColumnType
-		Name string
-	}{}
+	cols := table.schema()
+
+	// resolve every assignment against the schema before touching any row
+	indexes := make([]int, len(us.Set))
+	values := make([]*compiledExpression, len(us.Set))
+	for i, set := range us.Set {
+		indexes[i] = -1
+		for j, name := range table.columns {
+			if name == set.Column.value {
+				indexes[i] = j
+				break
+			}
+		}
+
+		if indexes[i] == -1 {
+			return 0, ErrColumnDoesNotExists
+		}
+
+		for _, j := range indexes[:i] {
+			if j == indexes[i] {
+				return 0, fmt.Errorf("%w: %s", ErrColumnDuplicated, set.Column.value)
+			}
+		}
+
+		value, err := mb.compileColumnValue(table, indexes[i], set.Value, cols)
+		if err != nil {
+			return 0, err
+		}
+
+		values[i] = value
+	}
 
-	// iterate over table rows
+	where, err := mb.compilePredicate(us.Where, cols)
+	if err != nil {
+		return 0, err
+	}
+
+	// compute every new row first so a failing expression leaves the table untouched
+	updated := map[int][]MemoryCell{}
 	for i, row := range table.rows {
-		result := []Cell{}
-		isFirstRow := i == 0
+		match, err := where.test(row)
+		if err != nil {
+			return 0, err
+		}
+		if !match {
+			continue
+		}
 
-		// iterate over the items (expression) we want to find in table
-		for _, exp := range ss.Item {
-			if exp.Kind != LiteralKind {
-				fmt.Println("Skipping non-literal expression")
-				continue
+		newRow := append([]MemoryCell{}, row...)
+		for j, value := range values {
+			// every value sees the row as it was before the update
+			cell, err := value.evaluate(row)
+			if err != nil {
+				return 0, err
+			}
+
+			newRow[indexes[j]] = cell
+		}
+
+		updated[i] = newRow
+	}
+
+	newRows := [][]MemoryCell{}
+	replaced := map[int]bool{}
+	for i := range table.rows {
+		if row, ok := updated[i]; ok {
+			newRows = append(newRows, row)
+			replaced[i] = true
+		}
+	}
+
+	if err := mb.checkConstraints(table, newRows, replaced); err != nil {
+		return 0, err
+	}
+
+	if err := mb.checkReferences(table, newRows, replaced); err != nil {
+		return 0, err
+	}
+
+	if err := mb.checkReferenced(table, newRows, replaced); err != nil {
+		return 0, err
+	}
+
+	for i, row := range updated {
+		table.rows[i] = row
+	}
+
+	return len(updated), nil
+}
+
+func (mb *MemoryBackend) Delete(ds *DeleteStatement) (int, error) {
+	table, ok := mb.tables[ds.Table.value]
+	if !ok {
+		return 0, ErrTableDoesNotExists
+	}
+
+	where, err := mb.compilePredicate(ds.Where, table.schema())
+	if err != nil {
+		return 0, err
+	}
+
+	// collect the deleted rows and whatever their foreign keys do to other tables first,
+	// so a failing predicate or a restricted delete leaves every table untouched
+	d := deletion{
+		deleted: map[*Table]map[int]bool{table: {}},
+		updated: map[*Table]map[int][]MemoryCell{table: {}},
+	}
+
+	removed := []int{}
+	for i, row := range table.rows {
+		match, err := where.test(row)
+		if err != nil {
+			return 0, err
+		}
+
+		if match {
+			d.deleted[table][i] = true
+			removed = append(removed, i)
+		}
+	}
+
+	if err := mb.cascade(&d, table, removed); err != nil {
+		return 0, err
+	}
+
+	if err := mb.apply(&d); err != nil {
+		return 0, err
+	}
+
+	return len(removed), nil
+}
+
+// referencedBy returns a table other than table itself with a foreign key referencing
+// table, or nil if there is none
+func (mb *MemoryBackend) referencedBy(table *Table) *Table {
+	for _, child := range mb.sortedTables() {
+		if child == table {
+			continue
+		}
+
+		for _, fk := range child.foreignKeys {
+			if fk.parent == table {
+				return child
+			}
+		}
+	}
+
+	return nil
+}
+
+func (mb *MemoryBackend) DropTable(ds *DropTableStatement) error {
+	table, ok := mb.tables[ds.Name.value]
+	if !ok {
+		if ds.IfExists {
+			return nil
+		}
+
+		return ErrTableDoesNotExists
+	}
+
+	if child := mb.referencedBy(table); child != nil {
+		return fmt.Errorf("%w: %s references %s", ErrTableReferenced, child.name, table.name)
+	}
+
+	delete(mb.tables, ds.Name.value)
+	return nil
+}
+
+// Truncate removes every row of a table at once, which isn't allowed while other
+// tables reference it since their rows would be left dangling
+func (mb *MemoryBackend) Truncate(ts *TruncateStatement) error {
+	table, ok := mb.tables[ts.Table.value]
+	if !ok {
+		return ErrTableDoesNotExists
+	}
+
+	if child := mb.referencedBy(table); child != nil {
+		return fmt.Errorf("%w: %s references %s", ErrTableReferenced, child.name, table.name)
+	}
+
+	table.rows = [][]MemoryCell{}
+	return nil
+}
+
+// resultColumn is the element type of Results.Columns
+type resultColumn = struct {
+	Type ColumnType
+	Name string
+}
+
+// compileProjection resolves the select list against the columns of the rows being
+// selected, so column metadata and lookup errors don't depend on there being any rows
+func (mb *MemoryBackend) compileProjection(items []*SelectItem, schema []column) ([]resultColumn, []*compiledExpression, error) {
+	cols := []resultColumn{}
+	exps := []*compiledExpression{}
+	for _, item := range items {
+		exp := item.Exp
+
+		// * and t.* expand to every column in declaration order
+		if exp.isStar() {
+			if item.As != nil {
+				return nil, nil, ErrInvalidSelectItem
+			}
+
+			found := false
+			for i, col := range schema {
+				if exp.Table != nil && exp.Table.value != col.table {
+					continue
+				}
+
+				cols = append(cols, resultColumn{
+					Type: col.typ,
+					Name: col.name,
+				})
+				exps = append(exps, columnReference(i, col.typ))
+				found = true
+			}
+
+			if !found && exp.Table != nil {
+				return nil, nil, ErrTableDoesNotExists
+			}
+
+			continue
+		}
+
+		ce, err := mb.compileExpression(exp, schema)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		cols = append(cols, resultColumn{
+			Type: ce.typ,
+			Name: item.name(),
+		})
+		exps = append(exps, ce)
+	}
+
+	return cols, exps, nil
+}
+
+// orderKey is an ORDER BY expression compiled against a projected row followed by
+// the source row it came from
+type orderKey struct {
+	exp  *compiledExpression
+	desc bool
+}
+
+// compileOrderBy resolves ORDER BY items, a bare name refers to an output column
+// before a source column and an integer refers to an output column by position
+func (mb *MemoryBackend) compileOrderBy(items []*OrderByItem, output []resultColumn, source []column) ([]orderKey, error) {
+	// output columns are only reachable by bare name or position, so they go unnamed
+	// when resolving anything else against the source columns behind them
+	cols := make([]column, len(output))
+	cols = append(cols, source...)
+
+	keys := []orderKey{}
+	for _, item := range items {
+		key := orderKey{
+			desc: item.Desc,
+		}
+
+		exp := item.Exp
+		if exp.Kind == LiteralKind && exp.Literal.kind == integerKind {
+			pos, err := strconv.Atoi(exp.Literal.value)
+			if err != nil || pos < 1 || pos > len(output) {
+				return nil, fmt.Errorf("%w: ORDER BY position %s is not in the select list", ErrColumnDoesNotExists, exp.Literal.value)
 			}
 
-			lit := exp.Literal
-			if lit.kind == identifierKind {
-				found := false
-
-				// iterate over the table columns to find a matching expression
-				for i, tableCol := range table.columns {
-					// if we found it
-					if tableCol == lit.value {
-						if isFirstRow {
-							// append column name and type of current column to columns list
-							cols = append(cols, struct {
-								Type ColumnType
-								Name string
-							}{
-								Type: table.columnTypes[i],
-								Name: lit.value,
-							})
-						}
-
-						// append the row to the rows list, this one represents a single row
-						result = append(result, row[i])
-						found = true
-						break
-					}
+			key.exp = columnReference(pos-1, output[pos-1].Type)
+		}
+
+		if key.exp == nil && exp.Kind == LiteralKind && exp.Literal.kind == identifierKind && exp.Table == nil {
+			for i, col := range output {
+				if col.Name != exp.Literal.value {
+					continue
 				}
 
-				// if no column matches our expression
-				if !found {
-					return nil, ErrColumnDoesNotExists
+				if key.exp != nil {
+					return nil, fmt.Errorf("%w: %s", ErrColumnAmbiguous, exp)
 				}
 
+				key.exp = columnReference(i, col.Type)
+			}
+		}
+
+		if key.exp == nil {
+			ce, err := mb.compileExpression(exp, cols)
+			if err != nil {
+				return nil, err
+			}
+
+			key.exp = ce
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// sortRows stably sorts rows by their precomputed ORDER BY cells
+func sortRows(rows [][]Cell, sortCells [][]MemoryCell, keys []orderKey) {
+	idx := make([]int, len(rows))
+	for i := range idx {
+		idx[i] = i
+	}
+
+	sort.SliceStable(idx, func(i, j int) bool {
+		a, b := sortCells[idx[i]], sortCells[idx[j]]
+		for k, key := range keys {
+			c := compareCells(a[k], b[k], key.exp.typ)
+			if c == 0 {
 				continue
 			}
 
-			return nil, ErrColumnDoesNotExists
+			if key.desc {
+				return c > 0
+			}
+
+			return c < 0
+		}
+
+		return false
+	})
+
+	sorted := make([][]Cell, len(rows))
+	for i, j := range idx {
+		sorted[i] = rows[j]
+	}
+	copy(rows, sorted)
+}
+
+// evaluateRowCount evaluates a constant LIMIT or OFFSET expression, returning -1 when
+// there is none
+func (mb *MemoryBackend) evaluateRowCount(exp *Expression, clause string) (int, error) {
+	if exp == nil {
+		return -1, nil
+	}
+
+	ce, err := mb.compileExpression(exp, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if ce.typ != IntType && ce.typ != BigIntType {
+		return 0, fmt.Errorf("%w: %s must be an integer, got %s", ErrTypeMismatch, clause, ce.typ)
+	}
+
+	cell, err := ce.evaluate(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	n := int(cell.AsInt64())
+	if n < 0 {
+		return 0, fmt.Errorf("%w: %s must not be negative", ErrInvalidExpression, clause)
+	}
+
+	return n, nil
+}
+
+func (mb *MemoryBackend) Select(ss *SelectStatement) (*Results, error) {
+	// without FROM there are no columns for * to stand for
+	if ss.From == nil {
+		for _, item := range ss.Item {
+			if item.Exp.isStar() {
+				return nil, fmt.Errorf("%w: %s needs a FROM clause", ErrInvalidSelectItem, item.Exp)
+			}
+		}
+	}
+
+	// get the rows to select from out of memory
+	schema, rows, err := mb.scanTableReference(ss.From)
+	if err != nil {
+		return nil, err
+	}
+
+	if ss.isGrouped() {
+		return mb.selectGrouped(ss, rows, schema)
+	}
+
+	return mb.selectRows(ss, rows, schema)
+}
+
+// selectRows runs rows laid out as schema through the WHERE clause, the select list,
+// ORDER BY and LIMIT/OFFSET of ss
+func (mb *MemoryBackend) selectRows(ss *SelectStatement, rows [][]MemoryCell, schema []column) (*Results, error) {
+	cols, items, err := mb.compileProjection(ss.Item, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	where, err := mb.compilePredicate(ss.Where, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	orderBy, err := mb.compileOrderBy(ss.OrderBy, cols, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := mb.evaluateRowCount(ss.Limit, "LIMIT")
+	if err != nil {
+		return nil, err
+	}
+
+	offset, err := mb.evaluateRowCount(ss.Offset, "OFFSET")
+	if err != nil {
+		return nil, err
+	}
+	offset = max(offset, 0)
+
+	results := [][]Cell{}
+	sortCells := [][]MemoryCell{}
+
+	// iterate over rows
+	for _, row := range rows {
+		// without ORDER BY the first rows found are the ones returned, so stop early
+		if len(orderBy) == 0 && limit >= 0 && len(results) == limit {
+			break
+		}
+
+		// skip rows the WHERE clause filters out
+		match, err := where.test(row)
+		if err != nil {
+			return nil, err
+		}
+		if !match {
+			continue
+		}
+
+		if len(orderBy) == 0 && offset > 0 {
+			offset--
+			continue
+		}
+
+		projected := make([]MemoryCell, len(items))
+		result := make([]Cell, len(items))
+		for i, item := range items {
+			cell, err := item.evaluate(row)
+			if err != nil {
+				return nil, err
+			}
+
+			projected[i] = cell
+			result[i] = cell
+		}
+
+		if len(orderBy) > 0 {
+			// sort keys see the output columns followed by the source row
+			keyRow := append(projected, row...)
+			keyCells := make([]MemoryCell, len(orderBy))
+			for i, key := range orderBy {
+				cell, err := key.exp.evaluate(keyRow)
+				if err != nil {
+					return nil, err
+				}
+
+				keyCells[i] = cell
+			}
+
+			sortCells = append(sortCells, keyCells)
 		}
 
-		// append the single row we have to the overall rows we need to output
 		results = append(results, result)
 	}
 
+	if len(orderBy) > 0 {
+		sortRows(results, sortCells, orderBy)
+
+		results = results[min(offset, len(results)):]
+		if limit >= 0 && limit < len(results) {
+			results = results[:limit]
+		}
+	}
+
 	return &Results{
 		Columns: cols,
 		Rows:    results,
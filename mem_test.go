@@ -0,0 +1,527 @@
+package memsql
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// execute runs every statement of source against mb, stopping at the first error,
+// and returns the results of the last SELECT
+func execute(mb *MemoryBackend, source string) (*Results, error) {
+	ast, err := Parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *Results
+	for _, stmt := range ast.Statements {
+		switch stmt.Kind {
+		case CreateTableKind:
+			err = mb.CreateTable(stmt.CreateTableStatement)
+		case InsertKind:
+			err = mb.Insert(stmt.InsertStatement)
+		case UpdateKind:
+			_, err = mb.Update(stmt.UpdateStatement)
+		case DeleteKind:
+			_, err = mb.Delete(stmt.DeleteStatement)
+		case DropTableKind:
+			err = mb.DropTable(stmt.DropTableStatement)
+		case TruncateKind:
+			err = mb.Truncate(stmt.TruncateStatement)
+		case AlterTableKind:
+			err = mb.AlterTable(stmt.AlterTableStatement)
+		case SelectKind:
+			res, err = mb.Select(stmt.SelectStatement)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// mustExecute is execute failing the test on an error
+func mustExecute(t *testing.T, mb *MemoryBackend, source string) *Results {
+	t.Helper()
+
+	res, err := execute(mb, source)
+	if err != nil {
+		t.Fatalf("%s: %s", source, err)
+	}
+
+	return res
+}
+
+// rows renders every cell of res as text, NULL cells as NULL
+func rows(res *Results) [][]string {
+	rendered := [][]string{}
+	for _, row := range res.Rows {
+		r := []string{}
+		for i, cell := range row {
+			if cell.IsNull() {
+				r = append(r, "NULL")
+				continue
+			}
+
+			r = append(r, FormatCell(cell, res.Columns[i].Type))
+		}
+
+		rendered = append(rendered, r)
+	}
+
+	return rendered
+}
+
+// expectRows runs query and compares the rows it returns with want
+func expectRows(t *testing.T, mb *MemoryBackend, query string, want [][]string) {
+	t.Helper()
+
+	if got := rows(mustExecute(t, mb, query)); !reflect.DeepEqual(got, want) {
+		t.Errorf("%s: got %v, want %v", query, got, want)
+	}
+}
+
+func TestAliasedExpressions(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table users (name text, age int);")
+	mustExecute(t, mb, "insert into users values ('ann', 30), ('bob', 41);")
+
+	res := mustExecute(t, mb, "select age + 1 as next_age, 'x' as tag from users;")
+	if res.Columns[0].Name != "next_age" || res.Columns[0].Type != IntType || res.Columns[1].Name != "tag" {
+		t.Errorf("unexpected columns %v", res.Columns)
+	}
+
+	if got, want := rows(res), [][]string{{"31", "x"}, {"42", "x"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestNullColumnConstraint(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table x (a int null, b text null default 'b');")
+	mustExecute(t, mb, "insert into x (a) values (null);")
+
+	expectRows(t, mb, "select a, b from x;", [][]string{{"NULL", "b"}})
+}
+
+func TestIntegerLiteralOutOfRange(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table b (v bigint);")
+	mustExecute(t, mb, "insert into b values (-9223372036854775808), (9223372036854775807);")
+
+	for _, source := range []string{
+		"select 99999999999999999999;",
+		"insert into b values (9223372036854775808);",
+		"select v from b where v > -9223372036854775809;",
+	} {
+		if _, err := execute(mb, source); !errors.Is(err, ErrIntegerOutOfRange) {
+			t.Errorf("%s: got %v, want %v", source, err, ErrIntegerOutOfRange)
+		}
+	}
+
+	expectRows(t, mb, "select v from b;", [][]string{{"-9223372036854775808"}, {"9223372036854775807"}})
+}
+
+// expectError runs source and checks it fails with want, any error will do when want
+// is nil
+func expectError(t *testing.T, mb *MemoryBackend, source string, want error) {
+	t.Helper()
+
+	_, err := execute(mb, source)
+	if err == nil || (want != nil && !errors.Is(err, want)) {
+		t.Errorf("%s: got %v, want %v", source, err, want)
+	}
+}
+
+// affected runs the UPDATE or DELETE in source and returns how many rows it changed
+func affected(t *testing.T, mb *MemoryBackend, source string) int {
+	t.Helper()
+
+	ast, err := Parse(source)
+	if err != nil {
+		t.Fatalf("%s: %s", source, err)
+	}
+
+	var n int
+	switch stmt := ast.Statements[0]; stmt.Kind {
+	case UpdateKind:
+		n, err = mb.Update(stmt.UpdateStatement)
+	case DeleteKind:
+		n, err = mb.Delete(stmt.DeleteStatement)
+	default:
+		t.Fatalf("%s: not an UPDATE or DELETE", source)
+	}
+
+	if err != nil {
+		t.Fatalf("%s: %s", source, err)
+	}
+
+	return n
+}
+
+func TestUpdate(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table users (name text, age int, score int);")
+	mustExecute(t, mb, "insert into users values ('ann', 30, 1), ('bob', 41, 2), ('cy', 17, 3);")
+
+	if n := affected(t, mb, "update users set age = age + 1 where age > 20;"); n != 2 {
+		t.Errorf("updated %d rows, want 2", n)
+	}
+	expectRows(t, mb, "select name, age from users;", [][]string{{"ann", "31"}, {"bob", "42"}, {"cy", "17"}})
+
+	// every expression reads the row as it was before the update
+	if n := affected(t, mb, "update users set age = score, score = age where name = 'cy';"); n != 1 {
+		t.Errorf("updated %d rows, want 1", n)
+	}
+	expectRows(t, mb, "select age, score from users where name = 'cy';", [][]string{{"3", "17"}})
+
+	if n := affected(t, mb, "update users set name = 'x' where age > 100;"); n != 0 {
+		t.Errorf("updated %d rows, want 0", n)
+	}
+
+	if n := affected(t, mb, "update users set score = 0;"); n != 3 {
+		t.Errorf("updated %d rows, want 3", n)
+	}
+	expectRows(t, mb, "select score from users;", [][]string{{"0"}, {"0"}, {"0"}})
+}
+
+func TestUpdateErrors(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table users (name text, age int);")
+	mustExecute(t, mb, "insert into users values ('ann', 30);")
+
+	expectError(t, mb, "update missing set age = 1;", ErrTableDoesNotExists)
+	expectError(t, mb, "update users set height = 1;", ErrColumnDoesNotExists)
+	expectError(t, mb, "update users set age = 'old';", ErrTypeMismatch)
+	expectError(t, mb, "update users set age = 1 where name;", ErrTypeMismatch)
+	expectError(t, mb, "update users set age = 1 where height = 1;", ErrColumnDoesNotExists)
+	expectError(t, mb, "update users set age = 1, age = 2;", ErrColumnDuplicated)
+	expectError(t, mb, "update users set age;", nil)
+
+	expectRows(t, mb, "select name, age from users;", [][]string{{"ann", "30"}})
+}
+
+func TestDelete(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table users (name text, age int);")
+	mustExecute(t, mb, "insert into users values ('ann', 30), ('bob', 41), ('cy', 17), ('dee', 41);")
+
+	if n := affected(t, mb, "delete from users where age = 41;"); n != 2 {
+		t.Errorf("deleted %d rows, want 2", n)
+	}
+	expectRows(t, mb, "select name from users;", [][]string{{"ann"}, {"cy"}})
+
+	if n := affected(t, mb, "delete from users where age > 100;"); n != 0 {
+		t.Errorf("deleted %d rows, want 0", n)
+	}
+
+	if n := affected(t, mb, "delete from users;"); n != 2 {
+		t.Errorf("deleted %d rows, want 2", n)
+	}
+	expectRows(t, mb, "select name from users;", [][]string{})
+
+	// the table is still there and usable
+	mustExecute(t, mb, "insert into users values ('eve', 22);")
+	expectRows(t, mb, "select name from users;", [][]string{{"eve"}})
+}
+
+func TestDeleteErrors(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table users (name text, age int);")
+	mustExecute(t, mb, "insert into users values ('ann', 30);")
+
+	expectError(t, mb, "delete from missing;", ErrTableDoesNotExists)
+	expectError(t, mb, "delete from users where height = 1;", ErrColumnDoesNotExists)
+	expectError(t, mb, "delete from users where name;", ErrTypeMismatch)
+	expectError(t, mb, "delete from users where age = 'x';", ErrTypeMismatch)
+	expectError(t, mb, "delete users;", nil)
+
+	expectRows(t, mb, "select name from users;", [][]string{{"ann"}})
+}
+
+// expectColumns checks the names and types of the columns res describes
+func expectColumns(t *testing.T, res *Results, names []string, types []ColumnType) {
+	t.Helper()
+
+	gotNames, gotTypes := []string{}, []ColumnType{}
+	for _, col := range res.Columns {
+		gotNames = append(gotNames, col.Name)
+		gotTypes = append(gotTypes, col.Type)
+	}
+
+	if !reflect.DeepEqual(gotNames, names) || !reflect.DeepEqual(gotTypes, types) {
+		t.Errorf("got columns %v %v, want %v %v", gotNames, gotTypes, names, types)
+	}
+}
+
+func TestSelectStar(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table users (id int, name text);")
+	mustExecute(t, mb, "create table pets (owner int, pet text, age int);")
+	mustExecute(t, mb, "insert into users values (1, 'ann'), (2, 'bob');")
+	mustExecute(t, mb, "insert into pets values (1, 'rex', 3);")
+
+	res := mustExecute(t, mb, "select * from users;")
+	expectColumns(t, res, []string{"id", "name"}, []ColumnType{IntType, TextType})
+	expectRows(t, mb, "select * from users;", [][]string{{"1", "ann"}, {"2", "bob"}})
+
+	res = mustExecute(t, mb, "select pets.*, users.* from users join pets on id = owner;")
+	expectColumns(t, res, []string{"owner", "pet", "age", "id", "name"}, []ColumnType{IntType, TextType, IntType, IntType, TextType})
+	expectRows(t, mb, "select p.*, name from users u join pets p on u.id = p.owner;", [][]string{{"1", "rex", "3", "ann"}})
+	expectRows(t, mb, "select *, id from users where id = 2;", [][]string{{"2", "bob", "2"}})
+}
+
+func TestSelectStarErrors(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table users (id int, name text);")
+
+	expectError(t, mb, "select *;", ErrInvalidSelectItem)
+	expectError(t, mb, "select users.*;", ErrInvalidSelectItem)
+	expectError(t, mb, "select * as everything from users;", nil)
+	expectError(t, mb, "select pets.* from users;", ErrTableDoesNotExists)
+	expectError(t, mb, "select u.* from users;", ErrTableDoesNotExists)
+	expectError(t, mb, "select * from missing;", ErrTableDoesNotExists)
+}
+
+func TestSelectEmptyTable(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table users (id int, name text, score real);")
+
+	res := mustExecute(t, mb, "select * from users;")
+	expectColumns(t, res, []string{"id", "name", "score"}, []ColumnType{IntType, TextType, FloatType})
+	if len(res.Rows) != 0 {
+		t.Errorf("got %d rows, want none", len(res.Rows))
+	}
+
+	res = mustExecute(t, mb, "select name, id + 1 as next, score from users where id > 1 order by name limit 5;")
+	expectColumns(t, res, []string{"name", "next", "score"}, []ColumnType{TextType, IntType, FloatType})
+
+	// names are resolved against the schema even when there is no row to read
+	expectError(t, mb, "select height from users;", ErrColumnDoesNotExists)
+	expectError(t, mb, "select id from users where height > 1;", ErrColumnDoesNotExists)
+	expectError(t, mb, "select id from users order by height;", ErrColumnDoesNotExists)
+	expectError(t, mb, "select id + name from users;", ErrTypeMismatch)
+}
+
+func TestOrderBy(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table users (name text, age int);")
+	mustExecute(t, mb, "insert into users values ('cy', 17), ('ann', 41), ('bob', 41), ('dee', 9), ('eve', null);")
+
+	expectRows(t, mb, "select name from users order by age, name;", [][]string{{"dee"}, {"cy"}, {"ann"}, {"bob"}, {"eve"}})
+	expectRows(t, mb, "select name from users order by age desc, name desc;", [][]string{{"eve"}, {"bob"}, {"ann"}, {"cy"}, {"dee"}})
+
+	// integers sort numerically, the sort is stable and aliases and positions can be used
+	expectRows(t, mb, "select name, age as a from users where age > 10 order by a;", [][]string{{"cy", "17"}, {"ann", "41"}, {"bob", "41"}})
+	expectRows(t, mb, "select name from users order by 1 desc limit 2;", [][]string{{"eve"}, {"dee"}})
+
+	expectError(t, mb, "select name from users order by height;", ErrColumnDoesNotExists)
+	expectError(t, mb, "select name from users order by 3;", nil)
+}
+
+func TestLimitOffset(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table n (v int);")
+	mustExecute(t, mb, "insert into n values (5), (4), (3), (2), (1);")
+
+	expectRows(t, mb, "select v from n limit 2;", [][]string{{"5"}, {"4"}})
+	expectRows(t, mb, "select v from n limit 2 offset 2;", [][]string{{"3"}, {"2"}})
+	expectRows(t, mb, "select v from n order by v limit 2 offset 1;", [][]string{{"2"}, {"3"}})
+	expectRows(t, mb, "select v from n limit 10 offset 4;", [][]string{{"1"}})
+	expectRows(t, mb, "select v from n limit 0;", [][]string{})
+	expectRows(t, mb, "select v from n offset 5;", [][]string{})
+}
+
+func TestConstraints(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table users (id int primary key, email text unique, name text not null, age int default 18 check (age >= 0));")
+	mustExecute(t, mb, "insert into users (id, email, name) values (1, 'a@x', 'ann'), (2, null, 'bob'), (3, null, 'cy');")
+	expectRows(t, mb, "select id, age from users;", [][]string{{"1", "18"}, {"2", "18"}, {"3", "18"}})
+
+	expectError(t, mb, "insert into users (id, name) values (1, 'dup');", ErrUniqueViolation)
+	expectError(t, mb, "insert into users (id, name) values (null, 'nobody');", ErrNotNullViolation)
+	expectError(t, mb, "insert into users (id, email, name) values (4, 'a@x', 'dee');", ErrUniqueViolation)
+	expectError(t, mb, "insert into users (id) values (4);", ErrNotNullViolation)
+	expectError(t, mb, "insert into users values (4, null, 'dee', -1);", ErrCheckViolation)
+	expectError(t, mb, "update users set id = 1 where id = 2;", ErrUniqueViolation)
+	expectError(t, mb, "update users set name = null;", ErrNotNullViolation)
+	expectError(t, mb, "update users set age = age - 20 where id = 3;", ErrCheckViolation)
+
+	// a check that is unknown passes, and a failing statement changes nothing
+	mustExecute(t, mb, "insert into users values (4, null, 'dee', null);")
+	expectError(t, mb, "insert into users (id, name) values (5, 'eve'), (5, 'fay');", ErrUniqueViolation)
+	expectRows(t, mb, "select count(*) from users;", [][]string{{"4"}})
+
+	mustExecute(t, mb, "update users set age = default where id = 4;")
+	expectRows(t, mb, "select age from users where id = 4;", [][]string{{"18"}})
+}
+
+func TestConstraintDefinitionErrors(t *testing.T) {
+	mb := NewMemoryBackend()
+
+	expectError(t, mb, "create table t (a int primary key, b int primary key);", ErrMultiplePrimaryKeys)
+	expectError(t, mb, "create table t (a int default 'x');", ErrTypeMismatch)
+	expectError(t, mb, "create table t (a int check (a));", ErrTypeMismatch)
+	expectError(t, mb, "create table t (a int check (b > 0));", ErrColumnDoesNotExists)
+	expectError(t, mb, "create table t (a int not);", nil)
+	expectError(t, mb, "create table t (a int primary);", nil)
+}
+
+func TestKeyAndCheckAsColumnNames(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table settings (key text primary key, check int not null check (check > 0));")
+	mustExecute(t, mb, "insert into settings (check, key) values (1, 'a'), (2, 'b');")
+
+	expectRows(t, mb, "select key, check from settings where key = 'b';", [][]string{{"b", "2"}})
+	expectRows(t, mb, "select s.key from settings s order by check desc;", [][]string{{"b"}, {"a"}})
+	expectError(t, mb, "insert into settings values ('c', 0);", ErrCheckViolation)
+}
+
+func TestInsertColumnsAndRows(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table t (a int, b text default 'none', c int);")
+	mustExecute(t, mb, "insert into t (c, a) values (3, 1), (6, 4);")
+	mustExecute(t, mb, "insert into t values (7, 'x', 9), (8, default, null);")
+	mustExecute(t, mb, "insert into t (b) values ('only');")
+
+	expectRows(t, mb, "select a, b, c from t;", [][]string{
+		{"1", "none", "3"},
+		{"4", "none", "6"},
+		{"7", "x", "9"},
+		{"8", "none", "NULL"},
+		{"NULL", "only", "NULL"},
+	})
+}
+
+func TestInsertErrors(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table t (a int, b text);")
+	mustExecute(t, mb, "insert into t values (1, 'x');")
+
+	expectError(t, mb, "insert into missing values (1);", ErrTableDoesNotExists)
+	expectError(t, mb, "insert into t (a, height) values (1, 2);", ErrColumnDoesNotExists)
+	expectError(t, mb, "insert into t (a, a) values (1, 2);", ErrColumnDuplicated)
+	expectError(t, mb, "insert into t (a, b) values (1);", ErrMissingValues)
+	expectError(t, mb, "insert into t (a) values (1, 'x');", ErrMissingValues)
+	expectError(t, mb, "insert into t values (1, 'x', 3);", ErrMissingValues)
+	expectError(t, mb, "insert into t values ('x', 1);", ErrTypeMismatch)
+
+	// one bad tuple keeps the others out as well
+	expectError(t, mb, "insert into t values (2, 'y'), (3, 'z'), ('w', 'w');", ErrTypeMismatch)
+	expectError(t, mb, "insert into t values (2, 'y'), (3);", ErrMissingValues)
+	expectRows(t, mb, "select a, b from t;", [][]string{{"1", "x"}})
+}
+
+func TestInsertSelectAndCreateTableAs(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table users (id int, name text, age int);")
+	mustExecute(t, mb, "insert into users values (1, 'ann', 30), (2, 'bob', 41), (3, 'cy', 17);")
+
+	mustExecute(t, mb, "create table adults as select id, upper(name) as name, age + 0.5 as age from users where age >= 18;")
+	res := mustExecute(t, mb, "select * from adults;")
+	expectColumns(t, res, []string{"id", "name", "age"}, []ColumnType{IntType, TextType, DecimalType})
+	expectRows(t, mb, "select * from adults;", [][]string{{"1", "ANN", "30.5"}, {"2", "BOB", "41.5"}})
+
+	// the new table is a copy, changing it leaves the source alone
+	mustExecute(t, mb, "insert into adults (name, id) select name, id + 10 from users where id = 3;")
+	mustExecute(t, mb, "insert into adults select id, name, age from users where id = 1;")
+	expectRows(t, mb, "select id, name, age from adults order by id;", [][]string{
+		{"1", "ANN", "30.5"},
+		{"1", "ann", "30"},
+		{"2", "BOB", "41.5"},
+		{"13", "cy", "NULL"},
+	})
+	expectRows(t, mb, "select count(*) from users;", [][]string{{"3"}})
+
+	mustExecute(t, mb, "create table stats as select count(*) as n, max(age) as oldest from users;")
+	expectRows(t, mb, "select n, oldest from stats;", [][]string{{"3", "41"}})
+
+	// a table can be filled from itself
+	mustExecute(t, mb, "insert into users select id + 3, name, age from users;")
+	expectRows(t, mb, "select count(*) from users;", [][]string{{"6"}})
+}
+
+func TestInsertSelectErrors(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table users (id int, name text);")
+
+	expectError(t, mb, "insert into users select name, id from users;", ErrTypeMismatch)
+	expectError(t, mb, "insert into users select id from users;", ErrMissingValues)
+	expectError(t, mb, "insert into users select id, name from missing;", ErrTableDoesNotExists)
+	expectError(t, mb, "create table users as select 1;", ErrTableAlreadyExists)
+	expectError(t, mb, "create table copy as select id, id from users;", ErrColumnDuplicated)
+	expectError(t, mb, "create table copy as select height from users;", ErrColumnDoesNotExists)
+	expectError(t, mb, "select * from copy;", ErrTableDoesNotExists)
+}
+
+func TestDropAndTruncate(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table t (a int);")
+	mustExecute(t, mb, "insert into t values (1), (2);")
+
+	expectError(t, mb, "create table t (b text);", ErrTableAlreadyExists)
+	mustExecute(t, mb, "create table if not exists t (b text);")
+	expectRows(t, mb, "select * from t;", [][]string{{"1"}, {"2"}})
+
+	mustExecute(t, mb, "truncate table t;")
+	expectRows(t, mb, "select * from t;", [][]string{})
+	mustExecute(t, mb, "insert into t values (3);")
+	expectRows(t, mb, "select * from t;", [][]string{{"3"}})
+
+	mustExecute(t, mb, "drop table t;")
+	expectError(t, mb, "select * from t;", ErrTableDoesNotExists)
+	expectError(t, mb, "drop table t;", ErrTableDoesNotExists)
+	expectError(t, mb, "truncate table t;", ErrTableDoesNotExists)
+	mustExecute(t, mb, "drop table if exists t;")
+
+	// the name can be used again
+	mustExecute(t, mb, "create table t (b text);")
+	expectRows(t, mb, "select * from t;", [][]string{})
+}
+
+func TestDropReferencedTable(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table parents (id int primary key);")
+	mustExecute(t, mb, "create table children (parent int references parents);")
+	mustExecute(t, mb, "create table nodes (id int primary key, parent int references nodes);")
+	mustExecute(t, mb, "insert into parents values (1);")
+	mustExecute(t, mb, "insert into children values (1);")
+
+	expectError(t, mb, "drop table parents;", ErrTableReferenced)
+	expectError(t, mb, "drop table if exists parents;", ErrTableReferenced)
+	expectError(t, mb, "truncate table parents;", ErrTableReferenced)
+	expectRows(t, mb, "select id from parents;", [][]string{{"1"}})
+
+	// a table that only references itself can go
+	mustExecute(t, mb, "drop table nodes;")
+	mustExecute(t, mb, "drop table children;")
+	mustExecute(t, mb, "drop table parents;")
+}
+
+func TestNumericAndBooleanTypes(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table v (b boolean, i int, g bigint, f real, d double precision, n decimal, m numeric);")
+	mustExecute(t, mb, "insert into v values (true, 1, 5000000000, 1.5, 2.5e3, 0.1, 10), (false, -2, -1, -0.25, 1e-3, 12.345, null);")
+
+	res := mustExecute(t, mb, "select * from v;")
+	expectColumns(t, res, []string{"b", "i", "g", "f", "d", "n", "m"},
+		[]ColumnType{BoolType, IntType, BigIntType, FloatType, FloatType, DecimalType, DecimalType})
+	expectRows(t, mb, "select * from v;", [][]string{
+		{"true", "1", "5000000000", "1.5", "2500", "0.1", "10"},
+		{"false", "-2", "-1", "-0.25", "0.001", "12.345", "NULL"},
+	})
+
+	// numbers of different types compare as the wider type, decimals exactly
+	expectRows(t, mb, "select i from v where g > i and n + 0.2 = 0.3;", [][]string{{"1"}})
+	expectRows(t, mb, "select b from v where not b;", [][]string{{"false"}})
+	expectRows(t, mb, "select i from v order by f;", [][]string{{"-2"}, {"1"}})
+
+	expectError(t, mb, "insert into v (i) values (3000000000);", ErrIntegerOutOfRange)
+	expectError(t, mb, "insert into v (b) values (1);", ErrTypeMismatch)
+	expectError(t, mb, "insert into v (i) values ('1');", ErrTypeMismatch)
+	expectError(t, mb, "select b from v where b = 1;", ErrTypeMismatch)
+	expectError(t, mb, "create table w (a varchar);", nil)
+}
@@ -3,6 +3,7 @@ package memsql
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 func tokenFromKeyword(k Keyword) Token {
@@ -55,11 +56,162 @@ func parseToken(tokens []*Token, ic uint, kind TokenKind) (*Token, uint, bool) {
 	return nil, ic, false
 }
 
-// parseExpression helper will look for a numeric, string, or identifier token
-func parseExpression(tokens []*Token, ic uint, _ Token) (*Expression, uint, bool) {
+// nonReservedKeywords are keywords that only mean something in a few places, so
+// they can still name columns
+var nonReservedKeywords = map[Keyword]bool{
+	keyKeyword:       true,
+	checkKeyword:     true,
+	leftKeyword:      true,
+	rightKeyword:     true,
+	columnKeyword:    true,
+	toKeyword:        true,
+	addKeyword:       true,
+	dateKeyword:      true,
+	timeKeyword:      true,
+	timestampKeyword: true,
+	intervalKeyword:  true,
+	jsonKeyword:      true,
+}
+
+// parseIdentifier helper will look for an identifier or a non reserved keyword,
+// which is read as an identifier
+func parseIdentifier(tokens []*Token, ic uint) (*Token, uint, bool) {
+	if t, cursor, ok := parseToken(tokens, ic, identifierKind); ok {
+		return t, cursor, true
+	}
+
+	if ic >= uint(len(tokens)) {
+		return nil, ic, false
+	}
+
+	cur := tokens[ic]
+	if cur.kind != keywordKind || !nonReservedKeywords[Keyword(cur.value)] {
+		return nil, ic, false
+	}
+
+	return &Token{
+		value:    cur.value,
+		kind:     identifierKind,
+		location: cur.location,
+	}, ic + 1, true
+}
+
+// bindingPower tells how tightly a binary operator holds on to its operands,
+// tokens that are not binary operators have no binding power
+func (t *Token) bindingPower() uint {
+	switch t.kind {
+	case keywordKind:
+		switch Keyword(t.value) {
+		case orKeyword:
+			return 1
+		case andKeyword:
+			return 2
+		case isKeyword, likeKeyword, ilikeKeyword, inKeyword, betweenKeyword:
+			return 4
+		}
+	case symbolKind:
+		switch Symbol(t.value) {
+		case eqSymbol, neqSymbol, ltSymbol, lteSymbol, gtSymbol, gteSymbol:
+			return 4
+		case concatSymbol, matchSymbol, imatchSymbol, notMatchSymbol, notImatchSymbol:
+			return 5
+		case plusSymbol, minusSymbol:
+			return 6
+		case asteriskSymbol, slashSymbol, percentSymbol:
+			return 7
+		case arrowSymbol, longArrowSymbol:
+			return 9
+		case castSymbol:
+			return 10
+		}
+	}
+
+	return 0
+}
+
+// notBindingPower sits between AND and the comparisons, so NOT a = 1 AND b = 2
+// negates only the first comparison
+const notBindingPower uint = 3
+
+// negativeBindingPower sits above multiplication, so -a * b negates only a
+const negativeBindingPower uint = 8
+
+// parseLiteralExpression helper will look for a numeric, string, hex, or identifier token,
+// NULL, TRUE, FALSE, DEFAULT, a star, a column reference qualified by its table, or
+// a string preceded by the type it holds
+func parseLiteralExpression(tokens []*Token, ic uint) (*Expression, uint, bool) {
 	cursor := ic
 
-	kinds := []TokenKind{identifierKind, textKind, integerKind}
+	// Look for DATE, TIME, TIMESTAMP, INTERVAL, or JSON followed by a string
+	for _, k := range []Keyword{dateKeyword, timeKeyword, timestampKeyword, intervalKeyword, jsonKeyword} {
+		typ, newCursor, ok := parseTokenAnother(tokens, cursor, tokenFromKeyword(k))
+		if !ok {
+			continue
+		}
+
+		// without a string the keyword names a column
+		t, newCursor, ok := parseToken(tokens, newCursor, textKind)
+		if !ok {
+			break
+		}
+
+		return &Expression{
+			Literal: t,
+			Type:    typ,
+			Kind:    LiteralKind,
+		}, newCursor, true
+	}
+
+	// Look for NULL, TRUE, FALSE, or DEFAULT which stands for a column's default value
+	for _, k := range []Keyword{nullKeyword, trueKeyword, falseKeyword, defaultKeyword} {
+		if t, newCursor, ok := parseTokenAnother(tokens, cursor, tokenFromKeyword(k)); ok {
+			return &Expression{
+				Literal: t,
+				Kind:    LiteralKind,
+			}, newCursor, true
+		}
+	}
+
+	// Look for *
+	if star, newCursor, ok := parseTokenAnother(tokens, cursor, tokenFromSymbol(asteriskSymbol)); ok {
+		return &Expression{
+			Literal: star,
+			Kind:    LiteralKind,
+		}, newCursor, true
+	}
+
+	// Look for t.col or t.*
+	if expectToken(tokens, cursor+1, tokenFromSymbol(dotSymbol)) {
+		table, newCursor, ok := parseToken(tokens, cursor, identifierKind)
+		if !ok {
+			return nil, ic, false
+		}
+		newCursor++
+
+		col, newCursor, ok := parseIdentifier(tokens, newCursor)
+		if !ok {
+			col, newCursor, ok = parseTokenAnother(tokens, newCursor, tokenFromSymbol(asteriskSymbol))
+		}
+		if !ok {
+			helpMessage(tokens, newCursor, "Expected column name after '.'")
+			return nil, ic, false
+		}
+
+		return &Expression{
+			Literal: col,
+			Table:   table,
+			Kind:    LiteralKind,
+		}, newCursor, true
+	}
+
+	if t, newCursor, ok := parseIdentifier(tokens, cursor); ok {
+		return &Expression{
+			Literal: t,
+			Kind:    LiteralKind,
+		}, newCursor, true
+	}
+
+	kinds := []TokenKind{textKind, integerKind, decimalKind, blobKind}
 	for _, kind := range kinds {
 		t, newCursor, ok := parseToken(tokens, cursor, kind)
 		if ok {
@@ -70,154 +222,1711 @@ func parseExpression(tokens []*Token, ic uint, _ Token) (*Expression, uint, bool
 		}
 	}
 
-	return nil, ic, false
+	return nil, ic, false
+}
+
+// parseOperand helper will look for a parenthesized expression, a prefix
+// operator applied to an operand, or a literal
+func parseOperand(tokens []*Token, ic uint, delimiters []Token) (*Expression, uint, bool) {
+	cursor := ic
+
+	// Look for a parenthesized expression
+	if _, newCursor, ok := parseTokenAnother(tokens, cursor, tokenFromSymbol(leftParenSymbol)); ok {
+		rightParenToken := tokenFromSymbol(rightParenSymbol)
+
+		exp, newCursor, ok := parseExpression(tokens, newCursor, []Token{rightParenToken}, 0)
+		if !ok {
+			helpMessage(tokens, cursor, "Expected expression after '('")
+			return nil, ic, false
+		}
+
+		_, newCursor, ok = parseTokenAnother(tokens, newCursor, rightParenToken)
+		if !ok {
+			helpMessage(tokens, newCursor, "Expected ')'")
+			return nil, ic, false
+		}
+
+		return exp, newCursor, true
+	}
+
+	// Look for NOT
+	if op, newCursor, ok := parseTokenAnother(tokens, cursor, tokenFromKeyword(notKeyword)); ok {
+		operand, newCursor, ok := parseExpression(tokens, newCursor, delimiters, notBindingPower)
+		if !ok {
+			helpMessage(tokens, newCursor, "Expected expression after NOT")
+			return nil, ic, false
+		}
+
+		return &Expression{
+			Unary: &UnaryExpression{
+				Operand: operand,
+				Op:      *op,
+			},
+			Kind: UnaryKind,
+		}, newCursor, true
+	}
+
+	// Look for a minus sign, a number after it becomes a negative number
+	if op, newCursor, ok := parseTokenAnother(tokens, cursor, tokenFromSymbol(minusSymbol)); ok {
+		for _, kind := range []TokenKind{integerKind, decimalKind} {
+			if n, newCursor, ok := parseToken(tokens, newCursor, kind); ok {
+				return &Expression{
+					Literal: &Token{
+						value:    "-" + n.value,
+						kind:     kind,
+						location: op.location,
+					},
+					Kind: LiteralKind,
+				}, newCursor, true
+			}
+		}
+
+		operand, newCursor, ok := parseExpression(tokens, newCursor, delimiters, negativeBindingPower)
+		if !ok {
+			helpMessage(tokens, newCursor, "Expected expression after '-'")
+			return nil, ic, false
+		}
+
+		return &Expression{
+			Unary: &UnaryExpression{
+				Operand: operand,
+				Op:      *op,
+			},
+			Kind: UnaryKind,
+		}, newCursor, true
+	}
+
+	// Look for CASE
+	if expectToken(tokens, cursor, tokenFromKeyword(caseKeyword)) {
+		return parseCaseExpression(tokens, cursor)
+	}
+
+	// Look for CAST
+	if expectToken(tokens, cursor, tokenFromKeyword(castKeyword)) {
+		return parseCastExpression(tokens, cursor)
+	}
+
+	// Look for a function call
+	if expectToken(tokens, cursor+1, tokenFromSymbol(leftParenSymbol)) {
+		if name, newCursor, ok := parseToken(tokens, cursor, identifierKind); ok {
+			if name.value == "extract" {
+				return parseExtractExpression(tokens, newCursor, *name, ic)
+			}
+
+			if name.value == "position" {
+				return parsePositionExpression(tokens, newCursor, *name, ic)
+			}
+
+			return parseCallExpression(tokens, newCursor, *name, ic)
+		}
+	}
+
+	return parseLiteralExpression(tokens, cursor)
+}
+
+// parseCallExpression helper will look for the parenthesized, comma separated
+// arguments of a call to the function called name
+func parseCallExpression(tokens []*Token, cursor uint, name Token, ic uint) (*Expression, uint, bool) {
+	rightParenToken := tokenFromSymbol(rightParenSymbol)
+
+	// Look for left parenthesis
+	_, cursor, ok := parseTokenAnother(tokens, cursor, tokenFromSymbol(leftParenSymbol))
+	if !ok {
+		helpMessage(tokens, cursor, "Expected '('")
+		return nil, ic, false
+	}
+
+	// Look for arguments
+	args, cursor, ok := parseExpressions(tokens, cursor, []Token{rightParenToken})
+	if !ok {
+		return nil, ic, false
+	}
+
+	// Look for right parenthesis
+	_, cursor, ok = parseTokenAnother(tokens, cursor, rightParenToken)
+	if !ok {
+		helpMessage(tokens, cursor, "Expected ')'")
+		return nil, ic, false
+	}
+
+	return &Expression{
+		Call: &CallExpression{
+			Name: name,
+			Args: *args,
+		},
+		Kind: CallKind,
+	}, cursor, true
+}
+
+// parseCaseExpression helper will look for CASE, an optional operand, any number of
+// WHEN ... THEN ... pairs, an optional ELSE and END
+func parseCaseExpression(tokens []*Token, ic uint) (*Expression, uint, bool) {
+	cursor := ic + 1
+	ce := &CaseExpression{}
+
+	// Look for the operand of the simple form
+	if !expectToken(tokens, cursor, tokenFromKeyword(whenKeyword)) {
+		operand, newCursor, ok := parseExpression(tokens, cursor, nil, 0)
+		if !ok {
+			helpMessage(tokens, cursor, "Expected expression or WHEN after CASE")
+			return nil, ic, false
+		}
+		cursor = newCursor
+
+		ce.Operand = operand
+	}
+
+	// Look for WHEN ... THEN ...
+	for {
+		_, newCursor, ok := parseTokenAnother(tokens, cursor, tokenFromKeyword(whenKeyword))
+		if !ok {
+			break
+		}
+
+		when, newCursor, ok := parseExpression(tokens, newCursor, nil, 0)
+		if !ok {
+			helpMessage(tokens, newCursor, "Expected expression after WHEN")
+			return nil, ic, false
+		}
+
+		_, newCursor, ok = parseTokenAnother(tokens, newCursor, tokenFromKeyword(thenKeyword))
+		if !ok {
+			helpMessage(tokens, newCursor, "Expected THEN")
+			return nil, ic, false
+		}
+
+		then, newCursor, ok := parseExpression(tokens, newCursor, nil, 0)
+		if !ok {
+			helpMessage(tokens, newCursor, "Expected expression after THEN")
+			return nil, ic, false
+		}
+		cursor = newCursor
+
+		ce.Whens = append(ce.Whens, when)
+		ce.Thens = append(ce.Thens, then)
+	}
+
+	if len(ce.Whens) == 0 {
+		helpMessage(tokens, cursor, "Expected WHEN")
+		return nil, ic, false
+	}
+
+	// Look for ELSE
+	if _, newCursor, ok := parseTokenAnother(tokens, cursor, tokenFromKeyword(elseKeyword)); ok {
+		els, newCursor, ok := parseExpression(tokens, newCursor, nil, 0)
+		if !ok {
+			helpMessage(tokens, newCursor, "Expected expression after ELSE")
+			return nil, ic, false
+		}
+		cursor = newCursor
+
+		ce.Else = els
+	}
+
+	// Look for END
+	_, cursor, ok := parseTokenAnother(tokens, cursor, tokenFromKeyword(endKeyword))
+	if !ok {
+		helpMessage(tokens, cursor, "Expected END")
+		return nil, ic, false
+	}
+
+	return &Expression{
+		Case: ce,
+		Kind: CaseKind,
+	}, cursor, true
+}
+
+// parseCastExpression helper will look for CAST and the parenthesized expression
+// AS type
+func parseCastExpression(tokens []*Token, ic uint) (*Expression, uint, bool) {
+	cursor := ic + 1
+
+	// Look for left parenthesis
+	_, cursor, ok := parseTokenAnother(tokens, cursor, tokenFromSymbol(leftParenSymbol))
+	if !ok {
+		helpMessage(tokens, cursor, "Expected '(' after CAST")
+		return nil, ic, false
+	}
+
+	// Look for the expression
+	operand, cursor, ok := parseExpression(tokens, cursor, []Token{tokenFromKeyword(asKeyword)}, 0)
+	if !ok {
+		helpMessage(tokens, cursor, "Expected expression to cast")
+		return nil, ic, false
+	}
+
+	// Look for AS
+	_, cursor, ok = parseTokenAnother(tokens, cursor, tokenFromKeyword(asKeyword))
+	if !ok {
+		helpMessage(tokens, cursor, "Expected AS")
+		return nil, ic, false
+	}
+
+	// Look for the type
+	typ, cursor, ok := parseDatatype(tokens, cursor)
+	if !ok {
+		helpMessage(tokens, cursor, "Expected type after AS")
+		return nil, ic, false
+	}
+
+	// Look for right parenthesis
+	_, cursor, ok = parseTokenAnother(tokens, cursor, tokenFromSymbol(rightParenSymbol))
+	if !ok {
+		helpMessage(tokens, cursor, "Expected ')'")
+		return nil, ic, false
+	}
+
+	return &Expression{
+		Cast: &CastExpression{
+			Operand: operand,
+			Type:    *typ,
+		},
+		Kind: CastKind,
+	}, cursor, true
+}
+
+// parseExtractExpression helper will look for the parenthesized field FROM source
+// of EXTRACT, which becomes a call taking the field name as a string
+func parseExtractExpression(tokens []*Token, cursor uint, name Token, ic uint) (*Expression, uint, bool) {
+	rightParenToken := tokenFromSymbol(rightParenSymbol)
+
+	// Look for left parenthesis
+	_, cursor, ok := parseTokenAnother(tokens, cursor, tokenFromSymbol(leftParenSymbol))
+	if !ok {
+		helpMessage(tokens, cursor, "Expected '('")
+		return nil, ic, false
+	}
+
+	// Look for the field
+	field, cursor, ok := parseToken(tokens, cursor, identifierKind)
+	if !ok {
+		helpMessage(tokens, cursor, "Expected field to extract")
+		return nil, ic, false
+	}
+
+	// Look for FROM
+	_, cursor, ok = parseTokenAnother(tokens, cursor, tokenFromKeyword(fromKeyword))
+	if !ok {
+		helpMessage(tokens, cursor, "Expected FROM")
+		return nil, ic, false
+	}
+
+	// Look for the source
+	source, cursor, ok := parseExpression(tokens, cursor, []Token{rightParenToken}, 0)
+	if !ok {
+		helpMessage(tokens, cursor, "Expected expression after FROM")
+		return nil, ic, false
+	}
+
+	// Look for right parenthesis
+	_, cursor, ok = parseTokenAnother(tokens, cursor, rightParenToken)
+	if !ok {
+		helpMessage(tokens, cursor, "Expected ')'")
+		return nil, ic, false
+	}
+
+	return &Expression{
+		Call: &CallExpression{
+			Name: name,
+			Args: []*Expression{
+				{
+					Literal: &Token{
+						value:    field.value,
+						kind:     textKind,
+						location: field.location,
+					},
+					Kind: LiteralKind,
+				},
+				source,
+			},
+		},
+		Kind: CallKind,
+	}, cursor, true
+}
+
+// parsePositionExpression helper will look for the parenthesized substring IN
+// string of POSITION, which becomes a call taking both in that order
+func parsePositionExpression(tokens []*Token, cursor uint, name Token, ic uint) (*Expression, uint, bool) {
+	rightParenToken := tokenFromSymbol(rightParenSymbol)
+	inToken := tokenFromKeyword(inKeyword)
+
+	// Look for left parenthesis
+	_, cursor, ok := parseTokenAnother(tokens, cursor, tokenFromSymbol(leftParenSymbol))
+	if !ok {
+		helpMessage(tokens, cursor, "Expected '('")
+		return nil, ic, false
+	}
+
+	// Look for the substring, which must bind tighter than IN
+	substring, cursor, ok := parseExpression(tokens, cursor, []Token{inToken}, inToken.bindingPower()+1)
+	if !ok {
+		helpMessage(tokens, cursor, "Expected substring to look for")
+		return nil, ic, false
+	}
+
+	// Look for IN
+	_, cursor, ok = parseTokenAnother(tokens, cursor, inToken)
+	if !ok {
+		helpMessage(tokens, cursor, "Expected IN")
+		return nil, ic, false
+	}
+
+	// Look for the string
+	s, cursor, ok := parseExpression(tokens, cursor, []Token{rightParenToken}, 0)
+	if !ok {
+		helpMessage(tokens, cursor, "Expected expression after IN")
+		return nil, ic, false
+	}
+
+	// Look for right parenthesis
+	_, cursor, ok = parseTokenAnother(tokens, cursor, rightParenToken)
+	if !ok {
+		helpMessage(tokens, cursor, "Expected ')'")
+		return nil, ic, false
+	}
+
+	return &Expression{
+		Call: &CallExpression{
+			Name: name,
+			Args: []*Expression{substring, s},
+		},
+		Kind: CallKind,
+	}, cursor, true
+}
+
+// parseIsNull helper will look for IS [NOT] NULL after operand, IS NOT NULL becomes
+// the negation of IS NULL
+func parseIsNull(tokens []*Token, ic uint, operand *Expression) (*Expression, uint, bool) {
+	cursor := ic
+
+	// Look for IS
+	is, cursor, ok := parseTokenAnother(tokens, cursor, tokenFromKeyword(isKeyword))
+	if !ok {
+		return nil, ic, false
+	}
+
+	// Look for NOT
+	not, cursor, negated := parseTokenAnother(tokens, cursor, tokenFromKeyword(notKeyword))
+
+	// Look for NULL
+	null, cursor, ok := parseTokenAnother(tokens, cursor, tokenFromKeyword(nullKeyword))
+	if !ok {
+		helpMessage(tokens, cursor, "Expected NULL after IS")
+		return nil, ic, false
+	}
+
+	exp := &Expression{
+		Binary: &BinaryExpression{
+			A: operand,
+			B: &Expression{
+				Literal: null,
+				Kind:    LiteralKind,
+			},
+			Op: *is,
+		},
+		Kind: BinaryKind,
+	}
+
+	if negated {
+		exp = &Expression{
+			Unary: &UnaryExpression{
+				Operand: exp,
+				Op:      *not,
+			},
+			Kind: UnaryKind,
+		}
+	}
+
+	return exp, cursor, true
+}
+
+// isPredicate reports whether t starts a predicate with more than one operand on
+// its right
+func isPredicate(t *Token) bool {
+	for _, k := range []Keyword{likeKeyword, ilikeKeyword, inKeyword, betweenKeyword} {
+		if kt := tokenFromKeyword(k); t.equals(&kt) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parsePredicate helper will look for [NOT] LIKE or ILIKE and a pattern with an
+// optional ESCAPE, [NOT] IN and a parenthesized list, or [NOT] BETWEEN two values
+// and AND, each taking operand on its left, the NOT forms become negations
+func parsePredicate(tokens []*Token, ic uint, operand *Expression, delimiters []Token, minBp uint) (*Expression, uint, bool) {
+	cursor := ic
+
+	// Look for NOT
+	not, cursor, negated := parseTokenAnother(tokens, cursor, tokenFromKeyword(notKeyword))
+
+	op := tokens[cursor]
+	cursor++
+
+	var exp *Expression
+	switch Keyword(op.value) {
+	case likeKeyword, ilikeKeyword:
+		// Look for the pattern
+		pattern, newCursor, ok := parseExpression(tokens, cursor, delimiters, minBp)
+		if !ok {
+			helpMessage(tokens, cursor, "Expected pattern after "+strings.ToUpper(op.value))
+			return nil, ic, false
+		}
+		cursor = newCursor
+
+		exp = &Expression{
+			Like: &LikeExpression{
+				Operand: operand,
+				Pattern: pattern,
+				Op:      *op,
+			},
+			Kind: LikeKind,
+		}
+
+		// Look for ESCAPE
+		if _, newCursor, ok := parseTokenAnother(tokens, cursor, tokenFromKeyword(escapeKeyword)); ok {
+			escape, newCursor, ok := parseExpression(tokens, newCursor, delimiters, minBp)
+			if !ok {
+				helpMessage(tokens, newCursor, "Expected escape character after ESCAPE")
+				return nil, ic, false
+			}
+			cursor = newCursor
+
+			exp.Like.Escape = escape
+		}
+	case inKeyword:
+		rightParenToken := tokenFromSymbol(rightParenSymbol)
+
+		// Look for left parenthesis
+		_, newCursor, ok := parseTokenAnother(tokens, cursor, tokenFromSymbol(leftParenSymbol))
+		if !ok {
+			helpMessage(tokens, cursor, "Expected '(' after IN")
+			return nil, ic, false
+		}
+
+		// Look for the list
+		list, newCursor, ok := parseExpressions(tokens, newCursor, []Token{rightParenToken})
+		if !ok || len(*list) == 0 {
+			helpMessage(tokens, newCursor, "Expected values in IN list")
+			return nil, ic, false
+		}
+
+		// Look for right parenthesis
+		_, newCursor, ok = parseTokenAnother(tokens, newCursor, rightParenToken)
+		if !ok {
+			helpMessage(tokens, newCursor, "Expected ')'")
+			return nil, ic, false
+		}
+		cursor = newCursor
+
+		exp = &Expression{
+			In: &InExpression{
+				Operand: operand,
+				List:    *list,
+			},
+			Kind: InKind,
+		}
+	case betweenKeyword:
+		// the bounds bind tighter than AND, so the AND in between ends the first one
+		low, newCursor, ok := parseExpression(tokens, cursor, delimiters, minBp)
+		if !ok {
+			helpMessage(tokens, cursor, "Expected value after BETWEEN")
+			return nil, ic, false
+		}
+
+		_, newCursor, ok = parseTokenAnother(tokens, newCursor, tokenFromKeyword(andKeyword))
+		if !ok {
+			helpMessage(tokens, newCursor, "Expected AND")
+			return nil, ic, false
+		}
+
+		high, newCursor, ok := parseExpression(tokens, newCursor, delimiters, minBp)
+		if !ok {
+			helpMessage(tokens, newCursor, "Expected value after AND")
+			return nil, ic, false
+		}
+		cursor = newCursor
+
+		exp = &Expression{
+			Between: &BetweenExpression{
+				Operand: operand,
+				Low:     low,
+				High:    high,
+			},
+			Kind: BetweenKind,
+		}
+	}
+
+	if negated {
+		exp = &Expression{
+			Unary: &UnaryExpression{
+				Operand: exp,
+				Op:      *not,
+			},
+			Kind: UnaryKind,
+		}
+	}
+
+	return exp, cursor, true
+}
+
+// parseExpression helper will look for an operand followed by any number of
+// binary operators, only taking operators that bind at least as tight as minBp
+func parseExpression(tokens []*Token, ic uint, delimiters []Token, minBp uint) (*Expression, uint, bool) {
+	cursor := ic
+
+	exp, cursor, ok := parseOperand(tokens, cursor, delimiters)
+	if !ok {
+		return nil, ic, false
+	}
+
+outer:
+	for cursor < uint(len(tokens)) {
+		op := tokens[cursor]
+		for _, d := range delimiters {
+			if d.equals(op) {
+				break outer
+			}
+		}
+
+		bp := op.bindingPower()
+
+		// NOT only continues an expression as NOT LIKE, NOT ILIKE, NOT IN or NOT BETWEEN
+		predicate := isPredicate(op)
+		if expectToken(tokens, cursor, tokenFromKeyword(notKeyword)) && cursor+1 < uint(len(tokens)) && isPredicate(tokens[cursor+1]) {
+			bp = tokens[cursor+1].bindingPower()
+			predicate = true
+		}
+
+		if bp == 0 || bp < minBp {
+			break
+		}
+
+		// :: only ever takes a type on its right
+		if op.kind == symbolKind && Symbol(op.value) == castSymbol {
+			typ, newCursor, ok := parseDatatype(tokens, cursor+1)
+			if !ok {
+				helpMessage(tokens, cursor+1, "Expected type after '::'")
+				return nil, ic, false
+			}
+
+			exp = &Expression{
+				Cast: &CastExpression{
+					Operand: exp,
+					Type:    *typ,
+				},
+				Kind: CastKind,
+			}
+			cursor = newCursor
+
+			continue
+		}
+
+		// IS only ever takes NULL on its right
+		if op.kind == keywordKind && Keyword(op.value) == isKeyword {
+			exp, cursor, ok = parseIsNull(tokens, cursor, exp)
+			if !ok {
+				return nil, ic, false
+			}
+
+			continue
+		}
+
+		if predicate {
+			exp, cursor, ok = parsePredicate(tokens, cursor, exp, delimiters, bp+1)
+			if !ok {
+				return nil, ic, false
+			}
+
+			continue
+		}
+
+		// operands on the right must bind tighter, so a - b - c groups to the left
+		b, newCursor, ok := parseExpression(tokens, cursor+1, delimiters, bp+1)
+		if !ok {
+			helpMessage(tokens, cursor+1, "Expected right operand")
+			return nil, ic, false
+		}
+
+		exp = &Expression{
+			Binary: &BinaryExpression{
+				A:  exp,
+				B:  b,
+				Op: *op,
+			},
+			Kind: BinaryKind,
+		}
+		cursor = newCursor
+	}
+
+	return exp, cursor, true
+}
+
+// parseExpressions helper will look for tokens separated by a comma until a delimiter is found
+func parseExpressions(tokens []*Token, ic uint, delimiters []Token) (*[]*Expression, uint, bool) {
+	cursor := ic
+
+	exps := []*Expression{}
+
+outer:
+	for {
+		if cursor >= uint(len(tokens)) {
+			return nil, ic, false
+		}
+
+		cur := tokens[cursor]
+		for _, d := range delimiters {
+			if d.equals(cur) {
+				break outer
+			}
+		}
+
+		// look for comma
+		if len(exps) > 0 {
+			if !expectToken(tokens, cursor, tokenFromSymbol(commaSymbol)) {
+				helpMessage(tokens, cursor, "Expected comma")
+				return nil, ic, false
+			}
+
+			cursor++
+		}
+
+		// look for expression
+		exp, newCursor, ok := parseExpression(tokens, cursor, append([]Token{tokenFromSymbol(commaSymbol)}, delimiters...), 0)
+		if !ok {
+			helpMessage(tokens, cursor, "Expected expression")
+			return nil, ic, false
+		}
+
+		cursor = newCursor
+		exps = append(exps, exp)
+	}
+
+	return &exps, cursor, true
+}
+
+// parseTableName helper will look for a table name followed by an optional alias,
+// with or without AS
+func parseTableName(tokens []*Token, ic uint) (*TableReference, uint, bool) {
+	cursor := ic
+
+	table, newCursor, ok := parseToken(tokens, cursor, identifierKind)
+	if !ok {
+		return nil, ic, false
+	}
+	cursor = newCursor
+
+	ref := TableReference{
+		Table: table,
+	}
+
+	hasAs := expectToken(tokens, cursor, tokenFromKeyword(asKeyword))
+	if hasAs {
+		cursor++
+	}
+
+	if as, newCursor, ok := parseToken(tokens, cursor, identifierKind); ok {
+		ref.As = as
+		cursor = newCursor
+	} else if hasAs {
+		helpMessage(tokens, cursor, "Expected alias after AS")
+		return nil, ic, false
+	}
+
+	return &ref, cursor, true
+}
+
+// parseJoinType helper will look for the keywords that introduce a join, a comma
+// being a cross join
+func parseJoinType(tokens []*Token, ic uint) (JoinType, uint, bool) {
+	cursor := ic
+
+	if expectToken(tokens, cursor, tokenFromSymbol(commaSymbol)) {
+		return CrossJoin, cursor + 1, true
+	}
+
+	joinType := InnerJoin
+	switch {
+	case expectToken(tokens, cursor, tokenFromKeyword(innerKeyword)):
+		cursor++
+	case expectToken(tokens, cursor, tokenFromKeyword(crossKeyword)):
+		joinType = CrossJoin
+		cursor++
+	case expectToken(tokens, cursor, tokenFromKeyword(leftKeyword)):
+		joinType = LeftJoin
+		cursor++
+	case expectToken(tokens, cursor, tokenFromKeyword(rightKeyword)):
+		joinType = RightJoin
+		cursor++
+	}
+
+	if (joinType == LeftJoin || joinType == RightJoin) && expectToken(tokens, cursor, tokenFromKeyword(outerKeyword)) {
+		cursor++
+	}
+
+	if !expectToken(tokens, cursor, tokenFromKeyword(joinKeyword)) {
+		if cursor != ic {
+			helpMessage(tokens, cursor, "Expected keyword JOIN")
+		}
+		return 0, ic, false
+	}
+
+	return joinType, cursor + 1, true
+}
+
+// parseTableReference helper will look for a table followed by any number of joins,
+// which group to the left
+func parseTableReference(tokens []*Token, ic uint, delimiter Token) (*TableReference, uint, bool) {
+	cursor := ic
+
+	ref, cursor, ok := parseTableName(tokens, cursor)
+	if !ok {
+		return nil, ic, false
+	}
+
+	for {
+		joinType, newCursor, ok := parseJoinType(tokens, cursor)
+		if !ok {
+			break
+		}
+		cursor = newCursor
+
+		right, newCursor, ok := parseTableName(tokens, cursor)
+		if !ok {
+			helpMessage(tokens, cursor, "Expected table name")
+			return nil, ic, false
+		}
+		cursor = newCursor
+
+		join := Join{
+			Left:  ref,
+			Right: right,
+			Type:  joinType,
+		}
+
+		// Look for ON, every join but a cross join needs one
+		if joinType != CrossJoin {
+			if !expectToken(tokens, cursor, tokenFromKeyword(onKeyword)) {
+				helpMessage(tokens, cursor, "Expected keyword ON")
+				return nil, ic, false
+			}
+			cursor++
+
+			on, newCursor, ok := parseExpression(tokens, cursor, []Token{tokenFromKeyword(joinKeyword), tokenFromKeyword(whereKeyword), delimiter}, 0)
+			if !ok {
+				helpMessage(tokens, cursor, "Expected ON conditionals")
+				return nil, ic, false
+			}
+
+			join.On = on
+			cursor = newCursor
+		}
+
+		ref = &TableReference{
+			Join: &join,
+		}
+	}
+
+	return ref, cursor, true
+}
+
+// parseSelectItems helper will look for expressions with an optional AS alias,
+// separated by a comma until a delimiter is found
+func parseSelectItems(tokens []*Token, ic uint, delimiters []Token) (*[]*SelectItem, uint, bool) {
+	cursor := ic
+
+	items := []*SelectItem{}
+
+outer:
+	for {
+		if cursor >= uint(len(tokens)) {
+			return nil, ic, false
+		}
+
+		cur := tokens[cursor]
+		for _, d := range delimiters {
+			if d.equals(cur) {
+				break outer
+			}
+		}
+
+		// look for comma
+		if len(items) > 0 {
+			if !expectToken(tokens, cursor, tokenFromSymbol(commaSymbol)) {
+				helpMessage(tokens, cursor, "Expected comma")
+				return nil, ic, false
+			}
+
+			cursor++
+		}
+
+		// look for expression
+		itemDelimiters := append([]Token{tokenFromSymbol(commaSymbol), tokenFromKeyword(asKeyword)}, delimiters...)
+		exp, newCursor, ok := parseExpression(tokens, cursor, itemDelimiters, 0)
+		if !ok {
+			helpMessage(tokens, cursor, "Expected expression")
+			return nil, ic, false
+		}
+		cursor = newCursor
+
+		item := SelectItem{
+			Exp: exp,
+		}
+
+		// look for AS alias
+		if expectToken(tokens, cursor, tokenFromKeyword(asKeyword)) {
+			cursor++
+
+			as, newCursor, ok := parseIdentifier(tokens, cursor)
+			if !ok {
+				helpMessage(tokens, cursor, "Expected alias after AS")
+				return nil, ic, false
+			}
+
+			item.As = as
+			cursor = newCursor
+		}
+
+		items = append(items, &item)
+	}
+
+	return &items, cursor, true
+}
+
+// parseOrderByItems helper will look for expressions each followed by an optional
+// ASC or DESC, separated by a comma until a delimiter is found
+func parseOrderByItems(tokens []*Token, ic uint, delimiters []Token) ([]*OrderByItem, uint, bool) {
+	cursor := ic
+
+	items := []*OrderByItem{}
+	itemDelimiters := append([]Token{tokenFromSymbol(commaSymbol)}, delimiters...)
+	for {
+		// look for comma
+		if len(items) > 0 {
+			if !expectToken(tokens, cursor, tokenFromSymbol(commaSymbol)) {
+				break
+			}
+
+			cursor++
+		}
+
+		// look for expression
+		exp, newCursor, ok := parseExpression(tokens, cursor, itemDelimiters, 0)
+		if !ok {
+			helpMessage(tokens, cursor, "Expected ORDER BY expression")
+			return nil, ic, false
+		}
+		cursor = newCursor
+
+		item := OrderByItem{
+			Exp: exp,
+		}
+
+		// look for direction, ascending by default
+		if expectToken(tokens, cursor, tokenFromKeyword(descKeyword)) {
+			item.Desc = true
+			cursor++
+		} else if expectToken(tokens, cursor, tokenFromKeyword(ascKeyword)) {
+			cursor++
+		}
+
+		items = append(items, &item)
+	}
+
+	return items, cursor, true
+}
+
+func parseSelectStatement(tokens []*Token, ic uint, delimiter Token) (*SelectStatement, uint, bool) {
+	cursor := ic
+
+	if !expectToken(tokens, cursor, tokenFromKeyword(selectKeyword)) {
+		return nil, ic, false
+	}
+
+	cursor++
+
+	slct := SelectStatement{}
+
+	items, newCursor, ok := parseSelectItems(tokens, cursor, []Token{tokenFromKeyword(fromKeyword), tokenFromKeyword(groupKeyword), tokenFromKeyword(orderKeyword), tokenFromKeyword(limitKeyword), delimiter})
+	if !ok {
+		return nil, ic, false
+	}
+
+	slct.Item = *items
+	cursor = newCursor
+
+	if expectToken(tokens, cursor, tokenFromKeyword(fromKeyword)) {
+		cursor++
+
+		fr, newCurs, ok := parseTableReference(tokens, cursor, delimiter)
+		if !ok {
+			helpMessage(tokens, cursor, "Expected FROM token")
+			return nil, ic, false
+		}
+
+		slct.From = fr
+		cursor = newCurs
+	}
+
+	// Look for WHERE
+	if expectToken(tokens, cursor, tokenFromKeyword(whereKeyword)) {
+		cursor++
+
+		where, newCursor, ok := parseExpression(tokens, cursor, []Token{tokenFromKeyword(groupKeyword), tokenFromKeyword(orderKeyword), tokenFromKeyword(limitKeyword), delimiter}, 0)
+		if !ok {
+			helpMessage(tokens, cursor, "Expected WHERE conditionals")
+			return nil, ic, false
+		}
+
+		slct.Where = where
+		cursor = newCursor
+	}
+
+	// Look for GROUP BY
+	if expectToken(tokens, cursor, tokenFromKeyword(groupKeyword)) {
+		cursor++
+
+		if !expectToken(tokens, cursor, tokenFromKeyword(byKeyword)) {
+			helpMessage(tokens, cursor, "Expected keyword BY")
+			return nil, ic, false
+		}
+		cursor++
+
+		groupBy, newCursor, ok := parseExpressions(tokens, cursor, []Token{tokenFromKeyword(havingKeyword), tokenFromKeyword(orderKeyword), tokenFromKeyword(limitKeyword), tokenFromKeyword(offsetKeyword), delimiter})
+		if !ok {
+			return nil, ic, false
+		}
+
+		slct.GroupBy = *groupBy
+		cursor = newCursor
+	}
+
+	// Look for HAVING
+	if expectToken(tokens, cursor, tokenFromKeyword(havingKeyword)) {
+		cursor++
+
+		having, newCursor, ok := parseExpression(tokens, cursor, []Token{tokenFromKeyword(orderKeyword), tokenFromKeyword(limitKeyword), delimiter}, 0)
+		if !ok {
+			helpMessage(tokens, cursor, "Expected HAVING conditionals")
+			return nil, ic, false
+		}
+
+		slct.Having = having
+		cursor = newCursor
+	}
+
+	// Look for ORDER BY
+	if expectToken(tokens, cursor, tokenFromKeyword(orderKeyword)) {
+		cursor++
+
+		if !expectToken(tokens, cursor, tokenFromKeyword(byKeyword)) {
+			helpMessage(tokens, cursor, "Expected keyword BY")
+			return nil, ic, false
+		}
+		cursor++
+
+		orderBy, newCursor, ok := parseOrderByItems(tokens, cursor, []Token{tokenFromKeyword(limitKeyword), delimiter})
+		if !ok {
+			return nil, ic, false
+		}
+
+		slct.OrderBy = orderBy
+		cursor = newCursor
+	}
+
+	// Look for LIMIT
+	if expectToken(tokens, cursor, tokenFromKeyword(limitKeyword)) {
+		cursor++
+
+		limit, newCursor, ok := parseExpression(tokens, cursor, []Token{tokenFromKeyword(offsetKeyword), delimiter}, 0)
+		if !ok {
+			helpMessage(tokens, cursor, "Expected LIMIT value")
+			return nil, ic, false
+		}
+
+		slct.Limit = limit
+		cursor = newCursor
+	}
+
+	// Look for OFFSET
+	if expectToken(tokens, cursor, tokenFromKeyword(offsetKeyword)) {
+		cursor++
+
+		offset, newCursor, ok := parseExpression(tokens, cursor, []Token{delimiter}, 0)
+		if !ok {
+			helpMessage(tokens, cursor, "Expected OFFSET value")
+			return nil, ic, false
+		}
+
+		slct.Offset = offset
+		cursor = newCursor
+	}
+
+	return &slct, cursor, true
+}
+
+func parseInsertStatement(tokens []*Token, ic uint, delimiter Token) (*InsertStatement, uint, bool) {
+	cursor := ic
+
+	// Look for INSERT
+	if !expectToken(tokens, cursor, tokenFromKeyword(insertKeyword)) {
+		return nil, ic, false
+	}
+	cursor++
+
+	// Look for INTO
+	if !expectToken(tokens, cursor, tokenFromKeyword(intoKeyword)) {
+		helpMessage(tokens, cursor, "Expected keyword INTO")
+		return nil, ic, false
+	}
+	cursor++
+
+	// Look for tableName
+	table, newCursor, ok := parseToken(tokens, cursor, identifierKind)
+	if !ok {
+		helpMessage(tokens, cursor, "Expected table name")
+		return nil, ic, false
+	}
+	cursor = newCursor
+
+	// Look for an optional column list
+	var columns []Token
+	if expectToken(tokens, cursor, tokenFromSymbol(leftParenSymbol)) {
+		columns, cursor, ok = parseColumnNames(tokens, cursor)
+		if !ok {
+			return nil, ic, false
+		}
+	}
+
+	// Look for a query to insert the rows of
+	if slct, newCursor, ok := parseSelectStatement(tokens, cursor, delimiter); ok {
+		return &InsertStatement{
+			Table:   *table,
+			Columns: columns,
+			Select:  slct,
+		}, newCursor, true
+	}
+
+	// Look for VALUES
+	if !expectToken(tokens, cursor, tokenFromKeyword(valuesKeyword)) {
+		helpMessage(tokens, cursor, "Expected keyword VALUES or SELECT")
+		return nil, ic, false
+	}
+	cursor++
+
+	tuples := []*[]*Expression{}
+	for {
+		// Look for left parenthesis
+		if !expectToken(tokens, cursor, tokenFromSymbol(leftParenSymbol)) {
+			helpMessage(tokens, cursor, "Expected '('")
+			return nil, ic, false
+		}
+		cursor++
+
+		// Look for expressions
+		values, newCursor, ok := parseExpressions(tokens, cursor, []Token{tokenFromSymbol(rightParenSymbol)})
+		if !ok {
+			return nil, ic, false
+		}
+		cursor = newCursor
+
+		// Look for right parenthesis
+		if !expectToken(tokens, cursor, tokenFromSymbol(rightParenSymbol)) {
+			helpMessage(tokens, cursor, "Expected ')'")
+			return nil, ic, false
+		}
+		cursor++
+
+		tuples = append(tuples, values)
+
+		// Look for another tuple
+		if !expectToken(tokens, cursor, tokenFromSymbol(commaSymbol)) {
+			break
+		}
+		cursor++
+	}
+
+	return &InsertStatement{
+		Table:   *table,
+		Columns: columns,
+		Values:  tuples,
+	}, cursor, true
+}
+
+func parseUpdateStatement(tokens []*Token, ic uint, delimiter Token) (*UpdateStatement, uint, bool) {
+	cursor := ic
+
+	// Look for UPDATE
+	if !expectToken(tokens, cursor, tokenFromKeyword(updateKeyword)) {
+		return nil, ic, false
+	}
+	cursor++
+
+	// Look for tableName
+	table, newCursor, ok := parseToken(tokens, cursor, identifierKind)
+	if !ok {
+		helpMessage(tokens, cursor, "Expected table name")
+		return nil, ic, false
+	}
+	cursor = newCursor
+
+	// Look for SET
+	if !expectToken(tokens, cursor, tokenFromKeyword(setKeyword)) {
+		helpMessage(tokens, cursor, "Expected keyword SET")
+		return nil, ic, false
+	}
+	cursor++
+
+	upd := UpdateStatement{
+		Table: *table,
+	}
+
+	delimiters := []Token{tokenFromSymbol(commaSymbol), tokenFromKeyword(whereKeyword), delimiter}
+	for {
+		// Look for comma
+		if len(upd.Set) > 0 {
+			if !expectToken(tokens, cursor, tokenFromSymbol(commaSymbol)) {
+				break
+			}
+			cursor++
+		}
+
+		// Look for column name
+		col, newCursor, ok := parseIdentifier(tokens, cursor)
+		if !ok {
+			helpMessage(tokens, cursor, "Expected column name")
+			return nil, ic, false
+		}
+		cursor = newCursor
+
+		// Look for =
+		if !expectToken(tokens, cursor, tokenFromSymbol(eqSymbol)) {
+			helpMessage(tokens, cursor, "Expected '='")
+			return nil, ic, false
+		}
+		cursor++
+
+		// Look for the new value
+		value, newCursor, ok := parseExpression(tokens, cursor, delimiters, 0)
+		if !ok {
+			helpMessage(tokens, cursor, "Expected expression")
+			return nil, ic, false
+		}
+		cursor = newCursor
+
+		upd.Set = append(upd.Set, &SetClause{
+			Column: *col,
+			Value:  value,
+		})
+	}
+
+	// Look for WHERE
+	if expectToken(tokens, cursor, tokenFromKeyword(whereKeyword)) {
+		cursor++
+
+		where, newCursor, ok := parseExpression(tokens, cursor, []Token{delimiter}, 0)
+		if !ok {
+			helpMessage(tokens, cursor, "Expected WHERE conditionals")
+			return nil, ic, false
+		}
+
+		upd.Where = where
+		cursor = newCursor
+	}
+
+	return &upd, cursor, true
+}
+
+func parseDeleteStatement(tokens []*Token, ic uint, delimiter Token) (*DeleteStatement, uint, bool) {
+	cursor := ic
+
+	// Look for DELETE
+	if !expectToken(tokens, cursor, tokenFromKeyword(deleteKeyword)) {
+		return nil, ic, false
+	}
+	cursor++
+
+	// Look for FROM
+	if !expectToken(tokens, cursor, tokenFromKeyword(fromKeyword)) {
+		helpMessage(tokens, cursor, "Expected keyword FROM")
+		return nil, ic, false
+	}
+	cursor++
+
+	// Look for tableName
+	table, newCursor, ok := parseToken(tokens, cursor, identifierKind)
+	if !ok {
+		helpMessage(tokens, cursor, "Expected table name")
+		return nil, ic, false
+	}
+	cursor = newCursor
+
+	del := DeleteStatement{
+		Table: *table,
+	}
+
+	// Look for WHERE
+	if expectToken(tokens, cursor, tokenFromKeyword(whereKeyword)) {
+		cursor++
+
+		where, newCursor, ok := parseExpression(tokens, cursor, []Token{delimiter}, 0)
+		if !ok {
+			helpMessage(tokens, cursor, "Expected WHERE conditionals")
+			return nil, ic, false
+		}
+
+		del.Where = where
+		cursor = newCursor
+	}
+
+	return &del, cursor, true
+}
+
+func parseDropTableStatement(tokens []*Token, ic uint, delimiter Token) (*DropTableStatement, uint, bool) {
+	cursor := ic
+
+	// Look for DROP
+	if !expectToken(tokens, cursor, tokenFromKeyword(dropKeyword)) {
+		return nil, ic, false
+	}
+	cursor++
+
+	// Look for TABLE
+	if !expectToken(tokens, cursor, tokenFromKeyword(tableKeyword)) {
+		helpMessage(tokens, cursor, "Expected keyword TABLE")
+		return nil, ic, false
+	}
+	cursor++
+
+	drop := DropTableStatement{}
+
+	// Look for IF EXISTS
+	if expectToken(tokens, cursor, tokenFromKeyword(ifKeyword)) {
+		cursor++
+
+		if !expectToken(tokens, cursor, tokenFromKeyword(existsKeyword)) {
+			helpMessage(tokens, cursor, "Expected EXISTS after IF")
+			return nil, ic, false
+		}
+		cursor++
+
+		drop.IfExists = true
+	}
+
+	// Look for tableName
+	table, newCursor, ok := parseToken(tokens, cursor, identifierKind)
+	if !ok {
+		helpMessage(tokens, cursor, "Expected table name")
+		return nil, ic, false
+	}
+
+	drop.Name = *table
+	return &drop, newCursor, true
+}
+
+func parseTruncateStatement(tokens []*Token, ic uint, delimiter Token) (*TruncateStatement, uint, bool) {
+	cursor := ic
+
+	// Look for TRUNCATE
+	if !expectToken(tokens, cursor, tokenFromKeyword(truncateKeyword)) {
+		return nil, ic, false
+	}
+	cursor++
+
+	// TABLE is optional
+	if expectToken(tokens, cursor, tokenFromKeyword(tableKeyword)) {
+		cursor++
+	}
+
+	// Look for tableName
+	table, newCursor, ok := parseToken(tokens, cursor, identifierKind)
+	if !ok {
+		helpMessage(tokens, cursor, "Expected table name")
+		return nil, ic, false
+	}
+
+	return &TruncateStatement{
+		Table: *table,
+	}, newCursor, true
 }
 
-// parseExpressions helper will look for tokens separated by a comma until a delimiter is found
-func parseExpressions(tokens []*Token, ic uint, delimiters []Token) (*[]*Expression, uint, bool) {
+func parseAlterTableStatement(tokens []*Token, ic uint, delimiter Token) (*AlterTableStatement, uint, bool) {
 	cursor := ic
 
-	exps := []*Expression{}
+	// Look for ALTER
+	if !expectToken(tokens, cursor, tokenFromKeyword(alterKeyword)) {
+		return nil, ic, false
+	}
+	cursor++
 
-outer:
-	for {
-		if cursor >= uint(len(tokens)) {
+	// Look for TABLE
+	if !expectToken(tokens, cursor, tokenFromKeyword(tableKeyword)) {
+		helpMessage(tokens, cursor, "Expected keyword TABLE")
+		return nil, ic, false
+	}
+	cursor++
+
+	// Look for tableName
+	table, newCursor, ok := parseToken(tokens, cursor, identifierKind)
+	if !ok {
+		helpMessage(tokens, cursor, "Expected table name")
+		return nil, ic, false
+	}
+	cursor = newCursor
+
+	alter := AlterTableStatement{
+		Table: *table,
+	}
+
+	switch {
+	case expectToken(tokens, cursor, tokenFromKeyword(addKeyword)):
+		cursor++
+		if expectToken(tokens, cursor, tokenFromKeyword(columnKeyword)) {
+			cursor++
+		}
+
+		cd, newCursor, ok := parseColumnDefinition(tokens, cursor, []Token{delimiter})
+		if !ok {
 			return nil, ic, false
 		}
+		cursor = newCursor
 
-		cur := tokens[cursor]
-		for _, d := range delimiters {
-			if d.equals(cur) {
-				break outer
-			}
+		alter.Operation = AddColumnOperation
+		alter.Column = cd
+
+	case expectToken(tokens, cursor, tokenFromKeyword(dropKeyword)):
+		cursor++
+		if expectToken(tokens, cursor, tokenFromKeyword(columnKeyword)) {
+			cursor++
 		}
 
-		// look for comma
-		if len(exps) > 0 {
-			if !expectToken(tokens, cursor, tokenFromSymbol(commaSymbol)) {
-				helpMessage(tokens, cursor, "Expected comma")
+		name, newCursor, ok := parseIdentifier(tokens, cursor)
+		if !ok {
+			helpMessage(tokens, cursor, "Expected column name")
+			return nil, ic, false
+		}
+		cursor = newCursor
+
+		alter.Operation = DropColumnOperation
+		alter.Name = *name
+
+	case expectToken(tokens, cursor, tokenFromKeyword(renameKeyword)):
+		cursor++
+
+		alter.Operation = RenameTableOperation
+		if !expectToken(tokens, cursor, tokenFromKeyword(toKeyword)) {
+			if expectToken(tokens, cursor, tokenFromKeyword(columnKeyword)) {
+				cursor++
+			}
+
+			name, newCursor, ok := parseIdentifier(tokens, cursor)
+			if !ok {
+				helpMessage(tokens, cursor, "Expected column name or TO")
 				return nil, ic, false
 			}
+			cursor = newCursor
 
-			cursor++
+			alter.Operation = RenameColumnOperation
+			alter.Name = *name
+
+			if !expectToken(tokens, cursor, tokenFromKeyword(toKeyword)) {
+				helpMessage(tokens, cursor, "Expected keyword TO")
+				return nil, ic, false
+			}
 		}
+		cursor++
 
-		// look for expression
-		exp, newCursor, ok := parseExpression(tokens, cursor, tokenFromSymbol(commaSymbol))
+		// tables can't be named by keywords, FROM wouldn't find them
+		newName, newCursor, ok := parseToken(tokens, cursor, identifierKind)
+		if alter.Operation == RenameColumnOperation {
+			newName, newCursor, ok = parseIdentifier(tokens, cursor)
+		}
 		if !ok {
-			helpMessage(tokens, cursor, "Expected expression")
+			helpMessage(tokens, cursor, "Expected new name")
 			return nil, ic, false
 		}
-
 		cursor = newCursor
-		exps = append(exps, exp)
+
+		alter.NewName = *newName
+
+	default:
+		helpMessage(tokens, cursor, "Expected ADD, DROP or RENAME")
+		return nil, ic, false
 	}
 
-	return &exps, cursor, true
+	return &alter, cursor, true
 }
 
-func parseSelectStatement(tokens []*Token, ic uint, delimiter Token) (*SelectStatement, uint, bool) {
+// parseColumnConstraints helper will look for any number of NOT NULL, NULL, PRIMARY
+// KEY, UNIQUE, DEFAULT <expression>, CHECK (<expression>) and REFERENCES constraints
+// on cd
+func parseColumnConstraints(tokens []*Token, ic uint, cd *ColumnDefinition, delimiters []Token) (uint, bool) {
 	cursor := ic
 
-	if !expectToken(tokens, cursor, tokenFromKeyword(selectKeyword)) {
-		return nil, ic, false
+	for {
+		switch {
+		case expectToken(tokens, cursor, tokenFromKeyword(notKeyword)):
+			cursor++
+
+			if !expectToken(tokens, cursor, tokenFromKeyword(nullKeyword)) {
+				helpMessage(tokens, cursor, "Expected NULL after NOT")
+				return ic, false
+			}
+			cursor++
+
+			cd.NotNull = true
+
+		case expectToken(tokens, cursor, tokenFromKeyword(nullKeyword)):
+			// NULL only says what is already the default
+			cursor++
+
+		case expectToken(tokens, cursor, tokenFromKeyword(primaryKeyword)):
+			cursor++
+
+			if !expectToken(tokens, cursor, tokenFromKeyword(keyKeyword)) {
+				helpMessage(tokens, cursor, "Expected KEY after PRIMARY")
+				return ic, false
+			}
+			cursor++
+
+			cd.PrimaryKey = true
+
+		case expectToken(tokens, cursor, tokenFromKeyword(uniqueKeyword)):
+			cursor++
+
+			cd.Unique = true
+
+		case expectToken(tokens, cursor, tokenFromKeyword(defaultKeyword)):
+			cursor++
+
+			exp, newCursor, ok := parseExpression(tokens, cursor, delimiters, 0)
+			if !ok {
+				helpMessage(tokens, cursor, "Expected DEFAULT value")
+				return ic, false
+			}
+
+			cd.Default = exp
+			cursor = newCursor
+
+		case expectToken(tokens, cursor, tokenFromKeyword(checkKeyword)):
+			cursor++
+
+			if !expectToken(tokens, cursor, tokenFromSymbol(leftParenSymbol)) {
+				helpMessage(tokens, cursor, "Expected '(' after CHECK")
+				return ic, false
+			}
+
+			// the parentheses are part of the expression
+			exp, newCursor, ok := parseExpression(tokens, cursor, delimiters, 0)
+			if !ok {
+				helpMessage(tokens, cursor, "Expected CHECK condition")
+				return ic, false
+			}
+
+			cd.Check = exp
+			cursor = newCursor
+
+		case expectToken(tokens, cursor, tokenFromKeyword(referencesKeyword)):
+			fk, newCursor, ok := parseReferences(tokens, cursor)
+			if !ok {
+				return ic, false
+			}
+
+			fk.Columns = []Token{cd.Name}
+			cd.References = fk
+			cursor = newCursor
+
+		default:
+			return cursor, true
+		}
 	}
+}
+
+// parseColumnNames parses a parenthesized, comma separated list of column names
+func parseColumnNames(tokens []*Token, ic uint) ([]Token, uint, bool) {
+	cursor := ic
 
+	if !expectToken(tokens, cursor, tokenFromSymbol(leftParenSymbol)) {
+		return nil, ic, false
+	}
 	cursor++
 
-	slct := SelectStatement{}
+	names := []Token{}
+	for {
+		name, newCursor, ok := parseIdentifier(tokens, cursor)
+		if !ok {
+			helpMessage(tokens, cursor, "Expected column name")
+			return nil, ic, false
+		}
+		cursor = newCursor
+		names = append(names, *name)
 
-	exps, newCursor, ok := parseExpressions(tokens, cursor, []Token{tokenFromKeyword(fromKeyword), delimiter})
-	if !ok {
+		if expectToken(tokens, cursor, tokenFromSymbol(rightParenSymbol)) {
+			return names, cursor + 1, true
+		}
+
+		if !expectToken(tokens, cursor, tokenFromSymbol(commaSymbol)) {
+			helpMessage(tokens, cursor, "Expected comma or ')'")
+			return nil, ic, false
+		}
+		cursor++
+	}
+}
+
+// parseReferences parses REFERENCES <table> [(<columns>)] [ON DELETE <action>]
+func parseReferences(tokens []*Token, ic uint) (*ForeignKey, uint, bool) {
+	cursor := ic
+
+	if !expectToken(tokens, cursor, tokenFromKeyword(referencesKeyword)) {
 		return nil, ic, false
 	}
+	cursor++
 
-	slct.Item = *exps
+	table, newCursor, ok := parseToken(tokens, cursor, identifierKind)
+	if !ok {
+		helpMessage(tokens, cursor, "Expected referenced table name")
+		return nil, ic, false
+	}
 	cursor = newCursor
 
-	if expectToken(tokens, cursor, tokenFromKeyword(fromKeyword)) {
-		cursor++
+	fk := ForeignKey{
+		Table: *table,
+	}
 
-		fr, newCurs, ok := parseToken(tokens, cursor, identifierKind)
+	if expectToken(tokens, cursor, tokenFromSymbol(leftParenSymbol)) {
+		fk.References, cursor, ok = parseColumnNames(tokens, cursor)
 		if !ok {
-			helpMessage(tokens, cursor, "Expected FROM token")
 			return nil, ic, false
 		}
+	}
 
-		slct.From = *fr
-		cursor = newCurs
+	if !expectToken(tokens, cursor, tokenFromKeyword(onKeyword)) {
+		return &fk, cursor, true
+	}
+	cursor++
+
+	if !expectToken(tokens, cursor, tokenFromKeyword(deleteKeyword)) {
+		helpMessage(tokens, cursor, "Expected DELETE after ON")
+		return nil, ic, false
 	}
+	cursor++
 
-	return &slct, cursor, true
+	switch {
+	case expectToken(tokens, cursor, tokenFromKeyword(cascadeKeyword)):
+		fk.OnDelete = CascadeAction
+	case expectToken(tokens, cursor, tokenFromKeyword(restrictKeyword)):
+		fk.OnDelete = RestrictAction
+	case expectToken(tokens, cursor, tokenFromKeyword(setKeyword)):
+		cursor++
+
+		if !expectToken(tokens, cursor, tokenFromKeyword(nullKeyword)) {
+			helpMessage(tokens, cursor, "Expected NULL after SET")
+			return nil, ic, false
+		}
+		fk.OnDelete = SetNullAction
+	default:
+		helpMessage(tokens, cursor, "Expected CASCADE, RESTRICT or SET NULL")
+		return nil, ic, false
+	}
+	cursor++
+
+	return &fk, cursor, true
 }
 
-func parseInsertStatement(tokens []*Token, ic uint, delimiter Token) (*InsertStatement, uint, bool) {
+// parseForeignKey parses a table level FOREIGN KEY (<columns>) REFERENCES ...
+func parseForeignKey(tokens []*Token, ic uint) (*ForeignKey, uint, bool) {
 	cursor := ic
 
-	// Look for INSERT
-	if !expectToken(tokens, cursor, tokenFromKeyword(insertKeyword)) {
+	if !expectToken(tokens, cursor, tokenFromKeyword(foreignKeyword)) {
 		return nil, ic, false
 	}
 	cursor++
 
-	// Look for INTO
-	if !expectToken(tokens, cursor, tokenFromKeyword(intoKeyword)) {
-		helpMessage(tokens, cursor, "Expected keyword INTO")
+	if !expectToken(tokens, cursor, tokenFromKeyword(keyKeyword)) {
+		helpMessage(tokens, cursor, "Expected KEY after FOREIGN")
 		return nil, ic, false
 	}
 	cursor++
 
-	// Look for tableName
-	table, newCursor, ok := parseToken(tokens, cursor, identifierKind)
+	columns, newCursor, ok := parseColumnNames(tokens, cursor)
 	if !ok {
-		helpMessage(tokens, cursor, "Expected table name")
+		helpMessage(tokens, cursor, "Expected foreign key columns")
 		return nil, ic, false
 	}
 	cursor = newCursor
 
-	// Look for VALUES
-	if !expectToken(tokens, cursor, tokenFromKeyword(valuesKeyword)) {
-		helpMessage(tokens, cursor, "Expected keyword VALUES")
+	fk, newCursor, ok := parseReferences(tokens, cursor)
+	if !ok {
+		helpMessage(tokens, cursor, "Expected REFERENCES")
 		return nil, ic, false
 	}
-	cursor++
+	fk.Columns = columns
 
-	// Look for left parenthesis
-	if !expectToken(tokens, cursor, tokenFromSymbol(leftParenSymbol)) {
-		helpMessage(tokens, cursor, "Expected '('")
+	return fk, newCursor, true
+}
+
+// parseDatatype helper will look for the keyword naming a type, DOUBLE may be spelled
+// DOUBLE PRECISION
+func parseDatatype(tokens []*Token, ic uint) (*Token, uint, bool) {
+	t, cursor, ok := parseToken(tokens, ic, keywordKind)
+	if !ok {
 		return nil, ic, false
 	}
-	cursor++
 
-	// Look for expressions
-	values, newCursor, ok := parseExpressions(tokens, cursor, []Token{tokenFromSymbol(rightParenSymbol)})
+	if Keyword(t.value) == doubleKeyword && expectToken(tokens, cursor, tokenFromKeyword(precisionKeyword)) {
+		cursor++
+	}
+
+	return t, cursor, true
+}
+
+// parseColumnDefinition parses a column name, its type and its constraints, which end
+// at one of the delimiters
+func parseColumnDefinition(tokens []*Token, ic uint, delimiters []Token) (*ColumnDefinition, uint, bool) {
+	cursor := ic
+
+	// Look for column name
+	id, newCursor, ok := parseIdentifier(tokens, cursor)
 	if !ok {
+		helpMessage(tokens, cursor, "Expected column name")
 		return nil, ic, false
 	}
 	cursor = newCursor
 
-	// Look for right parenthesis
-	if !expectToken(tokens, cursor, tokenFromSymbol(rightParenSymbol)) {
-		helpMessage(tokens, cursor, "Expected ')'")
+	// Look for column type
+	t, newCursor, ok := parseDatatype(tokens, cursor)
+	if !ok {
+		helpMessage(tokens, cursor, "Expected column type")
 		return nil, ic, false
 	}
-	cursor++
+	cursor = newCursor
 
-	return &InsertStatement{
-		Table:  *table,
-		Values: values,
-	}, cursor, true
+	cd := ColumnDefinition{
+		Name:     *id,
+		Datatype: *t,
+	}
+
+	// Look for column constraints
+	cursor, ok = parseColumnConstraints(tokens, cursor, &cd, delimiters)
+	if !ok {
+		return nil, ic, false
+	}
+
+	return &cd, cursor, true
 }
 
 // parseColumnDefinitions helper will look column names followed by column types
-// separated by a comma and ending with some delimiter
-func parseColumnDefinitions(tokens []*Token, ic uint, delimiter Token) (*[]*ColumnDefinition, uint, bool) {
+// separated by a comma and ending with some delimiter, table level foreign keys may
+// be mixed in with the columns
+func parseColumnDefinitions(tokens []*Token, ic uint, delimiter Token) (*[]*ColumnDefinition, []*ForeignKey, uint, bool) {
 	cursor := ic
 
 	var cds []*ColumnDefinition
+	fks := []*ForeignKey{}
 	for {
 		if cursor >= uint(len(tokens)) {
-			return nil, ic, false
+			return nil, nil, ic, false
 		}
 
 		// Look for delimiter
@@ -229,41 +1938,41 @@ func parseColumnDefinitions(tokens []*Token, ic uint, delimiter Token) (*[]*Colu
 		}
 
 		// Look for comma
-		if len(cds) > 0 {
+		if len(cds) > 0 || len(fks) > 0 {
 			var ok bool
 			_, cursor, ok = parseTokenAnother(tokens, cursor, tokenFromSymbol(commaSymbol))
 			if !ok {
 				helpMessage(tokens, cursor, "Expected comma")
-				return nil, ic, false
+				return nil, nil, ic, false
 			}
 		}
 
-		// Look for column name
-		id, newCursor, ok := parseToken(tokens, cursor, identifierKind)
-		if !ok {
-			helpMessage(tokens, cursor, "Expected column name")
-			return nil, ic, false
+		// Look for a table level foreign key
+		if expectToken(tokens, cursor, tokenFromKeyword(foreignKeyword)) {
+			fk, newCursor, ok := parseForeignKey(tokens, cursor)
+			if !ok {
+				return nil, nil, ic, false
+			}
+			cursor = newCursor
+
+			fks = append(fks, fk)
+			continue
 		}
-		cursor = newCursor
 
-		// Look for column type
-		t, newCursor, ok := parseToken(tokens, cursor, keywordKind)
+		// Look for column definition
+		cd, newCursor, ok := parseColumnDefinition(tokens, cursor, []Token{tokenFromSymbol(commaSymbol), delimiter})
 		if !ok {
-			helpMessage(tokens, cursor, "Expected column type")
-			return nil, ic, false
+			return nil, nil, ic, false
 		}
 		cursor = newCursor
 
-		cds = append(cds, &ColumnDefinition{
-			Name:     *id,
-			Datatype: *t,
-		})
+		cds = append(cds, cd)
 	}
 
-	return &cds, cursor, true
+	return &cds, fks, cursor, true
 }
 
-func parseCreateTableStatement(tokens []*Token, ic uint, _ Token) (*CreateTableStatement, uint, bool) {
+func parseCreateTableStatement(tokens []*Token, ic uint, delimiter Token) (*CreateTableStatement, uint, bool) {
 	cursor := ic
 	ok := false
 
@@ -279,6 +1988,20 @@ func parseCreateTableStatement(tokens []*Token, ic uint, _ Token) (*CreateTableS
 		return nil, ic, false
 	}
 
+	// Look for IF NOT EXISTS
+	ifNotExists := false
+	if expectToken(tokens, cursor, tokenFromKeyword(ifKeyword)) {
+		cursor++
+
+		if !expectToken(tokens, cursor, tokenFromKeyword(notKeyword)) || !expectToken(tokens, cursor+1, tokenFromKeyword(existsKeyword)) {
+			helpMessage(tokens, cursor, "Expected NOT EXISTS after IF")
+			return nil, ic, false
+		}
+		cursor += 2
+
+		ifNotExists = true
+	}
+
 	// Look for tableName
 	table, newCursor, ok := parseToken(tokens, cursor, identifierKind)
 	if !ok {
@@ -287,6 +2010,21 @@ func parseCreateTableStatement(tokens []*Token, ic uint, _ Token) (*CreateTableS
 	}
 	cursor = newCursor
 
+	// Look for AS followed by the query to create the table from
+	if expectToken(tokens, cursor, tokenFromKeyword(asKeyword)) {
+		slct, newCursor, ok := parseSelectStatement(tokens, cursor+1, delimiter)
+		if !ok {
+			helpMessage(tokens, cursor+1, "Expected SELECT after AS")
+			return nil, ic, false
+		}
+
+		return &CreateTableStatement{
+			Name:        *table,
+			IfNotExists: ifNotExists,
+			As:          slct,
+		}, newCursor, true
+	}
+
 	// Look for left parenthesis
 	_, cursor, ok = parseTokenAnother(tokens, cursor, tokenFromSymbol(leftParenSymbol))
 	if !ok {
@@ -295,7 +2033,7 @@ func parseCreateTableStatement(tokens []*Token, ic uint, _ Token) (*CreateTableS
 	}
 
 	// Look for column definitions
-	cols, newCursor, ok := parseColumnDefinitions(tokens, cursor, tokenFromSymbol(rightParenSymbol))
+	cols, fks, newCursor, ok := parseColumnDefinitions(tokens, cursor, tokenFromSymbol(rightParenSymbol))
 	if !ok {
 		return nil, ic, false
 	}
@@ -312,8 +2050,10 @@ func parseCreateTableStatement(tokens []*Token, ic uint, _ Token) (*CreateTableS
 
 	fmt.Println("Completed Create...")
 	return &CreateTableStatement{
-		Name:    *table,
-		Columns: cols,
+		Name:        *table,
+		IfNotExists: ifNotExists,
+		Columns:     cols,
+		ForeignKeys: fks,
 	}, cursor, true
 }
 
@@ -340,6 +2080,51 @@ func parseStatement(tokens []*Token, ic uint, _ Token) (*Statement, uint, bool)
 		}, newCursor, true
 	}
 
+	// Look for UPDATE statement
+	upd, newCursor, ok := parseUpdateStatement(tokens, cursor, semicolonToken)
+	if ok {
+		return &Statement{
+			UpdateStatement: upd,
+			Kind:            UpdateKind,
+		}, newCursor, true
+	}
+
+	// Look for DELETE statement
+	del, newCursor, ok := parseDeleteStatement(tokens, cursor, semicolonToken)
+	if ok {
+		return &Statement{
+			DeleteStatement: del,
+			Kind:            DeleteKind,
+		}, newCursor, true
+	}
+
+	// Look for DROP statement
+	drop, newCursor, ok := parseDropTableStatement(tokens, cursor, semicolonToken)
+	if ok {
+		return &Statement{
+			DropTableStatement: drop,
+			Kind:               DropTableKind,
+		}, newCursor, true
+	}
+
+	// Look for TRUNCATE statement
+	trnc, newCursor, ok := parseTruncateStatement(tokens, cursor, semicolonToken)
+	if ok {
+		return &Statement{
+			TruncateStatement: trnc,
+			Kind:              TruncateKind,
+		}, newCursor, true
+	}
+
+	// Look for ALTER statement
+	alter, newCursor, ok := parseAlterTableStatement(tokens, cursor, semicolonToken)
+	if ok {
+		return &Statement{
+			AlterTableStatement: alter,
+			Kind:                AlterTableKind,
+		}, newCursor, true
+	}
+
 	// Look for CREATE statement
 	ctstmt, newCursor, ok := parseCreateTableStatement(tokens, cursor, semicolonToken)
 	if ok {
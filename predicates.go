@@ -0,0 +1,295 @@
+package memsql
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// likeToRegexp translates a LIKE pattern, % matches any string and _ any single
+// character unless they follow the escape character, which is \ by default
+func likeToRegexp(pattern string, escape string, fold bool) (*regexp.Regexp, error) {
+	if len([]rune(escape)) > 1 {
+		return nil, fmt.Errorf("%w: ESCAPE expects a single character, got '%s'", ErrInvalidExpression, escape)
+	}
+
+	var b strings.Builder
+	b.WriteString("(?s)")
+	if fold {
+		b.WriteString("(?i)")
+	}
+	b.WriteString("^")
+
+	escaped := false
+	for _, c := range pattern {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+			escaped = false
+		case string(c) == escape:
+			escaped = true
+		case c == '%':
+			b.WriteString(".*")
+		case c == '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	if escaped {
+		return nil, fmt.Errorf("%w: LIKE pattern '%s' ends with the escape character", ErrInvalidExpression, pattern)
+	}
+
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// patternCache keeps the last pattern a predicate compiled, patterns are usually
+// the same for every row
+type patternCache struct {
+	key string
+	re  *regexp.Regexp
+}
+
+func (pc *patternCache) get(key string, compile func() (*regexp.Regexp, error)) (*regexp.Regexp, error) {
+	if pc.re != nil && pc.key == key {
+		return pc.re, nil
+	}
+
+	re, err := compile()
+	if err != nil {
+		return nil, err
+	}
+
+	pc.key, pc.re = key, re
+	return re, nil
+}
+
+// compileLike compiles LIKE and ILIKE, which is LIKE ignoring case
+func (mb *MemoryBackend) compileLike(le *LikeExpression, cols []column) (*compiledExpression, error) {
+	exps := []*Expression{le.Operand, le.Pattern}
+	if le.Escape != nil {
+		exps = append(exps, le.Escape)
+	}
+
+	args := []*compiledExpression{}
+	for _, exp := range exps {
+		arg, err := mb.compileExpression(exp, cols)
+		if err != nil {
+			return nil, err
+		}
+
+		if !fitsType(arg.typ, TextType) {
+			return nil, fmt.Errorf("%w: %s expects text, got %s", ErrTypeMismatch, strings.ToUpper(le.Op.value), arg.typ)
+		}
+
+		args = append(args, arg)
+	}
+
+	fold := Keyword(le.Op.value) == ilikeKeyword
+	cache := &patternCache{}
+	return &compiledExpression{
+		typ: BoolType,
+		evaluate: func(row []MemoryCell) (MemoryCell, error) {
+			cells := []MemoryCell{}
+			for _, arg := range args {
+				cell, err := arg.evaluate(row)
+				if err != nil || cell.IsNull() {
+					return nil, err
+				}
+
+				cells = append(cells, cell)
+			}
+
+			pattern, escape := cells[1].AsText(), `\`
+			if len(cells) > 2 {
+				escape = cells[2].AsText()
+			}
+
+			// the escape character is quoted so it can't run into the pattern
+			re, err := cache.get(strconv.Quote(escape)+pattern, func() (*regexp.Regexp, error) {
+				return likeToRegexp(pattern, escape, fold)
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return boolToCell(re.MatchString(cells[0].AsText())), nil
+		},
+	}, nil
+}
+
+// compileMatch compiles the regular expression operators, ~ tests whether a
+// string contains a match, ~* does so ignoring case and !~ and !~* negate them
+func compileMatch(op Symbol, a, b *compiledExpression) (*compiledExpression, error) {
+	if !fitsType(a.typ, TextType) || !fitsType(b.typ, TextType) {
+		return nil, fmt.Errorf("%w: %s expects text, got %s and %s", ErrTypeMismatch, op, a.typ, b.typ)
+	}
+
+	fold := op == imatchSymbol || op == notImatchSymbol
+	negated := op == notMatchSymbol || op == notImatchSymbol
+	cache := &patternCache{}
+	return &compiledExpression{
+		typ: BoolType,
+		evaluate: func(row []MemoryCell) (MemoryCell, error) {
+			l, err := a.evaluate(row)
+			if err != nil {
+				return nil, err
+			}
+
+			r, err := b.evaluate(row)
+			if err != nil || l.IsNull() || r.IsNull() {
+				return nil, err
+			}
+
+			pattern := r.AsText()
+			re, err := cache.get(pattern, func() (*regexp.Regexp, error) {
+				if fold {
+					pattern = "(?i)" + pattern
+				}
+
+				re, err := regexp.Compile(pattern)
+				if err != nil {
+					return nil, fmt.Errorf("%w: invalid regular expression: %s", ErrInvalidExpression, err)
+				}
+
+				return re, nil
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return boolToCell(re.MatchString(l.AsText()) != negated), nil
+		},
+	}, nil
+}
+
+// compileComparable compiles exps and converts them to the type they can all be
+// compared as
+func (mb *MemoryBackend) compileComparable(exps []*Expression, cols []column) ([]*compiledExpression, ColumnType, error) {
+	compiled := []*compiledExpression{}
+	typ := NullType
+	for _, exp := range exps {
+		ce, err := mb.compileExpression(exp, cols)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		compiled = append(compiled, ce)
+	}
+
+	// strings are read as the date or time they are compared with
+	temporal := NullType
+	for _, ce := range compiled {
+		if isTemporal(ce.typ) {
+			temporal = ce.typ
+			break
+		}
+	}
+
+	for i, ce := range compiled {
+		ce, err := coerceLiteral(exps[i], ce, temporal)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		common, ok := commonType(typ, ce.typ)
+		if !ok {
+			return nil, 0, fmt.Errorf("%w: cannot compare %s with %s", ErrTypeMismatch, typ, ce.typ)
+		}
+
+		typ = common
+		compiled[i] = ce
+	}
+
+	for i, ce := range compiled {
+		compiled[i] = coerce(ce, typ)
+	}
+
+	return compiled, typ, nil
+}
+
+// compileIn compiles IN, which is true when the operand equals an item of the list
+// and otherwise unknown if either contains NULL
+func (mb *MemoryBackend) compileIn(ie *InExpression, cols []column) (*compiledExpression, error) {
+	compiled, typ, err := mb.compileComparable(append([]*Expression{ie.Operand}, ie.List...), cols)
+	if err != nil {
+		return nil, err
+	}
+
+	operand, list := compiled[0], compiled[1:]
+	return &compiledExpression{
+		typ: BoolType,
+		evaluate: func(row []MemoryCell) (MemoryCell, error) {
+			v, err := operand.evaluate(row)
+			if err != nil || v.IsNull() {
+				return nil, err
+			}
+
+			unknown := false
+			for _, item := range list {
+				cell, err := item.evaluate(row)
+				if err != nil {
+					return nil, err
+				}
+
+				if cell.IsNull() {
+					unknown = true
+					continue
+				}
+
+				if compareCells(v, cell, typ) == 0 {
+					return boolToCell(true), nil
+				}
+			}
+
+			if unknown {
+				return nil, nil
+			}
+
+			return boolToCell(false), nil
+		},
+	}, nil
+}
+
+// compileBetween compiles BETWEEN, which is the same as operand >= low AND
+// operand <= high
+func (mb *MemoryBackend) compileBetween(be *BetweenExpression, cols []column) (*compiledExpression, error) {
+	compiled, typ, err := mb.compileComparable([]*Expression{be.Operand, be.Low, be.High}, cols)
+	if err != nil {
+		return nil, err
+	}
+
+	return &compiledExpression{
+		typ: BoolType,
+		evaluate: func(row []MemoryCell) (MemoryCell, error) {
+			cells := make([]MemoryCell, len(compiled))
+			for i, ce := range compiled {
+				cell, err := ce.evaluate(row)
+				if err != nil {
+					return nil, err
+				}
+
+				cells[i] = cell
+			}
+
+			v, low, high := cells[0], cells[1], cells[2]
+			if v.IsNull() {
+				return nil, nil
+			}
+
+			// a bound that is known to fail decides, even if the other one is NULL
+			if (!low.IsNull() && compareCells(v, low, typ) < 0) || (!high.IsNull() && compareCells(v, high, typ) > 0) {
+				return boolToCell(false), nil
+			}
+
+			if low.IsNull() || high.IsNull() {
+				return nil, nil
+			}
+
+			return boolToCell(true), nil
+		},
+	}, nil
+}
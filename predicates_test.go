@@ -0,0 +1,81 @@
+package memsql
+
+import "testing"
+
+func TestLike(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table w (id int, s text);")
+	mustExecute(t, mb, "insert into w values (1, 'Apple'), (2, 'apricot'), (3, '100%'), (4, 'a_b'), (5, 'axb'), (6, null), (7, 'line\none');")
+
+	expectRows(t, mb, "select id from w where s like 'a%';", [][]string{{"2"}, {"4"}, {"5"}})
+	expectRows(t, mb, "select id from w where s ilike 'a%';", [][]string{{"1"}, {"2"}, {"4"}, {"5"}})
+	expectRows(t, mb, "select id from w where s not like 'a%';", [][]string{{"1"}, {"3"}, {"7"}})
+	expectRows(t, mb, "select id from w where s not ilike 'A_B';", [][]string{{"1"}, {"2"}, {"3"}, {"7"}})
+	expectRows(t, mb, "select id from w where s like 'a_b';", [][]string{{"4"}, {"5"}})
+	expectRows(t, mb, "select id from w where s like 'a\\_b';", [][]string{{"4"}})
+	expectRows(t, mb, "select id from w where s like 'a!_b' escape '!';", [][]string{{"4"}})
+	expectRows(t, mb, "select id from w where s like '%!%' escape '!';", [][]string{{"3"}})
+	expectRows(t, mb, "select id from w where s like 'line%';", [][]string{{"7"}})
+	expectRows(t, mb, "select s like null, null like 'a', 'a.c' like 'abc', 'a+' like 'a+' from w where id = 1;", [][]string{{"NULL", "NULL", "false", "true"}})
+
+	// the pattern and the escape character can change from row to row
+	mustExecute(t, mb, "create table p (pattern text, esc text);")
+	mustExecute(t, mb, "insert into p values ('ab%', ''), ('b%', 'a');")
+	expectRows(t, mb, "select 'ab%' like pattern escape esc, 'abc' like pattern escape esc from p;", [][]string{{"true", "true"}, {"false", "false"}})
+}
+
+func TestInAndBetween(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table v (id int, n int, d decimal);")
+	mustExecute(t, mb, "insert into v values (1, 1, 1.5), (2, 5, 2), (3, null, null), (4, 10, 10.25);")
+
+	expectRows(t, mb, "select id from v where n in (1, 10);", [][]string{{"1"}, {"4"}})
+	expectRows(t, mb, "select id from v where n not in (1, 10);", [][]string{{"2"}})
+	expectRows(t, mb, "select id from v where d in (2, 10.25);", [][]string{{"2"}, {"4"}})
+	expectRows(t, mb, "select n in (1, null), n not in (1, null), null in (1) from v where id < 3;", [][]string{
+		{"true", "false", "NULL"},
+		{"NULL", "NULL", "NULL"},
+	})
+
+	expectRows(t, mb, "select id from v where n between 1 and 5;", [][]string{{"1"}, {"2"}})
+	expectRows(t, mb, "select id from v where n not between 2 and 9;", [][]string{{"1"}, {"4"}})
+	expectRows(t, mb, "select id from v where d between 1.5 and n;", [][]string{{"2"}})
+	expectRows(t, mb, "select id from v where n between 5 and 1;", [][]string{})
+	expectRows(t, mb, "select 3 between 1 and null, 0 between 1 and null, 'b' between 'a' and 'c';", [][]string{{"NULL", "false", "true"}})
+}
+
+func TestRegexMatch(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table w (id int, s text);")
+	mustExecute(t, mb, "insert into w values (1, 'Hello world'), (2, 'hello there'), (3, 'goodbye'), (4, null);")
+
+	expectRows(t, mb, "select id from w where s ~ '^hello';", [][]string{{"2"}})
+	expectRows(t, mb, "select id from w where s ~* '^hello';", [][]string{{"1"}, {"2"}})
+	expectRows(t, mb, "select id from w where s !~ 'o\\sw';", [][]string{{"2"}, {"3"}})
+	expectRows(t, mb, "select id from w where s !~* 'HELLO';", [][]string{{"3"}})
+	expectRows(t, mb, "select s ~ null, s ~ 'd$' from w where id in (1, 4);", [][]string{{"NULL", "true"}, {"NULL", "NULL"}})
+}
+
+func TestPredicateErrors(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table w (n int, s text, d date);")
+	mustExecute(t, mb, "insert into w values (1, 'a', '2026-01-01');")
+
+	expectError(t, mb, "select n like '1' from w;", ErrTypeMismatch)
+	expectError(t, mb, "select s ilike n from w;", ErrTypeMismatch)
+	expectError(t, mb, "select s like 'a' escape 1 from w;", ErrTypeMismatch)
+	expectError(t, mb, "select s like 'a' escape '!!' from w;", ErrInvalidExpression)
+	expectError(t, mb, "select s like 'a\\' from w;", ErrInvalidExpression)
+
+	expectError(t, mb, "select s ~ '(' from w;", ErrInvalidExpression)
+	expectError(t, mb, "select s ~* '[a' from w;", ErrInvalidExpression)
+	expectError(t, mb, "select n ~ '1' from w;", ErrTypeMismatch)
+	expectError(t, mb, "select s !~ n from w;", ErrTypeMismatch)
+
+	expectError(t, mb, "select n in ('a', 'b') from w;", ErrTypeMismatch)
+	expectError(t, mb, "select n in (1, true) from w;", ErrTypeMismatch)
+	expectError(t, mb, "select d in ('bogus') from w;", ErrInvalidTextRepresentation)
+	expectError(t, mb, "select n between 'a' and 2 from w;", ErrTypeMismatch)
+	expectError(t, mb, "select s between 1 and 2 from w;", ErrTypeMismatch)
+	expectError(t, mb, "select n in () from w;", nil)
+}
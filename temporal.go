@@ -0,0 +1,600 @@
+package memsql
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	microsecondsPerDay = int64(24 * time.Hour / time.Microsecond)
+	// daysPerMonth is how long a month is taken to be when intervals are compared
+	daysPerMonth = 30
+)
+
+// dates are stored as the days since 1970-01-01, times as the microseconds since
+// midnight and timestamps as the microseconds since 1970-01-01 00:00:00
+func dateToCell(t time.Time) MemoryCell {
+	return int32ToCell(int32(floorDiv(t.Unix(), 24*60*60)))
+}
+
+func timeToCell(micros int64) MemoryCell {
+	return int64ToCell(micros)
+}
+
+func timestampToCell(t time.Time) MemoryCell {
+	return int64ToCell(t.Round(time.Microsecond).UnixMicro())
+}
+
+func intervalToCell(iv Interval) MemoryCell {
+	cell := int32ToCell(iv.Months)
+	cell = append(cell, int32ToCell(iv.Days)...)
+	return append(cell, int64ToCell(iv.Microseconds)...)
+}
+
+// floorDiv divides rounding down, so times before 1970 land on the right day
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+
+	return q
+}
+
+func isTemporal(typ ColumnType) bool {
+	return typ == DateType || typ == TimeType || typ == TimestampType || typ == IntervalType
+}
+
+// parseTemporal reads the text of a DATE, TIME, TIMESTAMP or INTERVAL literal
+func parseTemporal(s string, typ ColumnType) (MemoryCell, error) {
+	s = strings.TrimSpace(s)
+
+	layouts := map[ColumnType][]string{
+		DateType:      {"2006-01-02"},
+		TimeType:      {"15:04:05", "15:04"},
+		TimestampType: {"2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02 15:04", "2006-01-02"},
+	}
+
+	if typ == IntervalType {
+		iv, err := parseInterval(s)
+		if err != nil {
+			return nil, err
+		}
+
+		return intervalToCell(iv), nil
+	}
+
+	for _, layout := range layouts[typ] {
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			continue
+		}
+
+		switch typ {
+		case DateType:
+			return dateToCell(t), nil
+		case TimeType:
+			return timeToCell(t.Round(time.Microsecond).Sub(time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC)).Microseconds()), nil
+		}
+
+		return timestampToCell(t), nil
+	}
+
+	return nil, fmt.Errorf("%w for %s: '%s'", ErrInvalidTextRepresentation, typ, s)
+}
+
+// intervalUnits are the microseconds in each unit shorter than a day
+var intervalUnits = map[string]int64{
+	"hour":        int64(time.Hour / time.Microsecond),
+	"minute":      int64(time.Minute / time.Microsecond),
+	"min":         int64(time.Minute / time.Microsecond),
+	"second":      int64(time.Second / time.Microsecond),
+	"sec":         int64(time.Second / time.Microsecond),
+	"millisecond": int64(time.Millisecond / time.Microsecond),
+	"microsecond": 1,
+}
+
+// parseClock reads [-]hh:mm[:ss[.ffffff]] as microseconds
+func parseClock(s string) (int64, error) {
+	sign := int64(1)
+	if strings.HasPrefix(s, "-") {
+		sign, s = -1, s[1:]
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("%w: invalid time %s", ErrInvalidTextRepresentation, s)
+	}
+
+	h, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid time %s", ErrInvalidTextRepresentation, s)
+	}
+
+	m, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid time %s", ErrInvalidTextRepresentation, s)
+	}
+
+	micros := (h*60 + m) * intervalUnits["minute"]
+	if len(parts) == 3 {
+		sec, ok := new(big.Rat).SetString(parts[2])
+		if !ok {
+			return 0, fmt.Errorf("%w: invalid time %s", ErrInvalidTextRepresentation, s)
+		}
+
+		micros += roundRat(sec.Mul(sec, big.NewRat(intervalUnits["second"], 1))).Int64()
+	}
+
+	return sign * micros, nil
+}
+
+// parseInterval reads intervals such as '1 year 2 months', '3 days 04:05:06' or
+// '-90 minutes'
+func parseInterval(s string) (Interval, error) {
+	iv := Interval{}
+	outOfRange := fmt.Errorf("%w: interval '%s'", ErrNumericOutOfRange, s)
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) == 0 {
+		return iv, fmt.Errorf("%w for interval: '%s'", ErrInvalidTextRepresentation, s)
+	}
+
+	for i := 0; i < len(fields); i++ {
+		if strings.Contains(fields[i], ":") {
+			micros, err := parseClock(fields[i])
+			if err != nil {
+				return iv, err
+			}
+
+			iv.Microseconds += micros
+			continue
+		}
+
+		if i+1 >= len(fields) {
+			return iv, fmt.Errorf("%w: missing unit in interval '%s'", ErrInvalidTextRepresentation, s)
+		}
+
+		n, ok := new(big.Rat).SetString(fields[i])
+		if !ok {
+			return iv, fmt.Errorf("%w for interval: '%s'", ErrInvalidTextRepresentation, s)
+		}
+
+		unit := strings.TrimSuffix(fields[i+1], "s")
+		i++
+
+		// years, months, weeks and days have to be whole, they don't split into
+		// microseconds
+		if micros, ok := intervalUnits[unit]; ok {
+			total := roundRat(n.Mul(n, big.NewRat(micros, 1)))
+			total.Add(total, big.NewInt(iv.Microseconds))
+			if !total.IsInt64() {
+				return iv, outOfRange
+			}
+
+			iv.Microseconds = total.Int64()
+			continue
+		}
+
+		if !n.IsInt() {
+			return iv, fmt.Errorf("%w: %s must be a whole number of %ss", ErrInvalidTextRepresentation, fields[i-1], unit)
+		}
+
+		whole := n.Num()
+		if !whole.IsInt64() || whole.Int64() > math.MaxInt32 || whole.Int64() < math.MinInt32 {
+			return iv, outOfRange
+		}
+
+		ok = true
+		switch unit {
+		case "year":
+			iv.Months, ok = addIntervalField(iv.Months, 12*whole.Int64())
+		case "mon", "month":
+			iv.Months, ok = addIntervalField(iv.Months, whole.Int64())
+		case "week":
+			iv.Days, ok = addIntervalField(iv.Days, 7*whole.Int64())
+		case "day":
+			iv.Days, ok = addIntervalField(iv.Days, whole.Int64())
+		default:
+			return iv, fmt.Errorf("%w: unknown interval unit %s", ErrInvalidTextRepresentation, fields[i])
+		}
+
+		if !ok {
+			return iv, outOfRange
+		}
+	}
+
+	return iv, nil
+}
+
+// addIntervalField adds n to the months or days of an interval, reporting false when
+// the sum doesn't fit in an int32
+func addIntervalField(field int32, n int64) (int32, bool) {
+	sum := int64(field) + n
+	if sum > math.MaxInt32 || sum < math.MinInt32 {
+		return 0, false
+	}
+
+	return int32(sum), true
+}
+
+func (iv Interval) String() string {
+	parts := []string{}
+	plural := func(n int32, unit string) {
+		if n == 1 || n == -1 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, unit))
+		} else if n != 0 {
+			parts = append(parts, fmt.Sprintf("%d %ss", n, unit))
+		}
+	}
+
+	plural(iv.Months/12, "year")
+	plural(iv.Months%12, "month")
+	plural(iv.Days, "day")
+
+	if iv.Microseconds != 0 || len(parts) == 0 {
+		micros, sign := iv.Microseconds, ""
+		if micros < 0 {
+			micros, sign = -micros, "-"
+		}
+
+		clock := time.UnixMicro(micros).UTC()
+		hours := micros / intervalUnits["hour"]
+		parts = append(parts, fmt.Sprintf("%s%02d:%s", sign, hours, clock.Format("04:05.999999")))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// compareIntervals orders intervals by their length, taking a month to be 30 days
+func compareIntervals(a, b Interval) int {
+	normalize := func(iv Interval) (int64, int64) {
+		days := int64(iv.Months)*daysPerMonth + int64(iv.Days) + floorDiv(iv.Microseconds, microsecondsPerDay)
+		return days, iv.Microseconds - floorDiv(iv.Microseconds, microsecondsPerDay)*microsecondsPerDay
+	}
+
+	ad, am := normalize(a)
+	bd, bm := normalize(b)
+	switch {
+	case ad != bd:
+		if ad < bd {
+			return -1
+		}
+		return 1
+	case am != bm:
+		if am < bm {
+			return -1
+		}
+		return 1
+	}
+
+	return 0
+}
+
+// addInterval moves t by sign times iv, months first and clamped to the end of the
+// month the way 2026-01-31 plus a month is 2026-02-28, then days and then the rest
+func addInterval(t time.Time, iv Interval, sign int) time.Time {
+	months := sign * int(iv.Months)
+	if months != 0 {
+		year, month, day := t.Date()
+		first := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+		last := first.AddDate(0, 1, -1).Day()
+		t = time.Date(first.Year(), first.Month(), min(day, last), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	}
+
+	t = t.AddDate(0, 0, sign*int(iv.Days))
+	return t.Add(time.Duration(int64(sign)*iv.Microseconds) * time.Microsecond)
+}
+
+// temporalOperation is what an arithmetic operator does to a pair of operands of
+// which at least one is temporal
+type temporalOperation struct {
+	op       Symbol
+	a, b     ColumnType
+	typ      ColumnType
+	evaluate func(x, y MemoryCell) (MemoryCell, error)
+}
+
+var temporalOperations = []temporalOperation{
+	{plusSymbol, DateType, IntType, DateType, func(x, y MemoryCell) (MemoryCell, error) {
+		return int32ToCell(x.AsInt32() + y.AsInt32()), nil
+	}},
+	{minusSymbol, DateType, IntType, DateType, func(x, y MemoryCell) (MemoryCell, error) {
+		return int32ToCell(x.AsInt32() - y.AsInt32()), nil
+	}},
+	{minusSymbol, DateType, DateType, IntType, func(x, y MemoryCell) (MemoryCell, error) {
+		return int32ToCell(x.AsInt32() - y.AsInt32()), nil
+	}},
+	{plusSymbol, DateType, TimeType, TimestampType, func(x, y MemoryCell) (MemoryCell, error) {
+		return int64ToCell(int64(x.AsInt32())*microsecondsPerDay + y.AsInt64()), nil
+	}},
+	{plusSymbol, DateType, IntervalType, TimestampType, func(x, y MemoryCell) (MemoryCell, error) {
+		return timestampToCell(addInterval(x.AsTime(), y.AsInterval(), 1)), nil
+	}},
+	{minusSymbol, DateType, IntervalType, TimestampType, func(x, y MemoryCell) (MemoryCell, error) {
+		return timestampToCell(addInterval(x.AsTime(), y.AsInterval(), -1)), nil
+	}},
+	{plusSymbol, TimestampType, IntervalType, TimestampType, func(x, y MemoryCell) (MemoryCell, error) {
+		return timestampToCell(addInterval(x.AsTime(), y.AsInterval(), 1)), nil
+	}},
+	{minusSymbol, TimestampType, IntervalType, TimestampType, func(x, y MemoryCell) (MemoryCell, error) {
+		return timestampToCell(addInterval(x.AsTime(), y.AsInterval(), -1)), nil
+	}},
+	{minusSymbol, TimestampType, TimestampType, IntervalType, func(x, y MemoryCell) (MemoryCell, error) {
+		diff := x.AsInt64() - y.AsInt64()
+		return intervalToCell(Interval{
+			Days:         int32(diff / microsecondsPerDay),
+			Microseconds: diff % microsecondsPerDay,
+		}), nil
+	}},
+	// times of day wrap around midnight
+	{plusSymbol, TimeType, IntervalType, TimeType, func(x, y MemoryCell) (MemoryCell, error) {
+		micros := x.AsInt64() + y.AsInterval().Microseconds
+		return timeToCell(micros - floorDiv(micros, microsecondsPerDay)*microsecondsPerDay), nil
+	}},
+	{minusSymbol, TimeType, IntervalType, TimeType, func(x, y MemoryCell) (MemoryCell, error) {
+		micros := x.AsInt64() - y.AsInterval().Microseconds
+		return timeToCell(micros - floorDiv(micros, microsecondsPerDay)*microsecondsPerDay), nil
+	}},
+	{minusSymbol, TimeType, TimeType, IntervalType, func(x, y MemoryCell) (MemoryCell, error) {
+		return intervalToCell(Interval{Microseconds: x.AsInt64() - y.AsInt64()}), nil
+	}},
+	{plusSymbol, IntervalType, IntervalType, IntervalType, func(x, y MemoryCell) (MemoryCell, error) {
+		return addIntervals(x.AsInterval(), y.AsInterval(), 1)
+	}},
+	{minusSymbol, IntervalType, IntervalType, IntervalType, func(x, y MemoryCell) (MemoryCell, error) {
+		return addIntervals(x.AsInterval(), y.AsInterval(), -1)
+	}},
+	// intervals scale by integers, the factor may come first when multiplying
+	{asteriskSymbol, IntervalType, IntType, IntervalType, func(x, y MemoryCell) (MemoryCell, error) {
+		return scaleInterval(x.AsInterval(), new(big.Rat).SetInt64(y.AsInt64()))
+	}},
+	{asteriskSymbol, IntervalType, BigIntType, IntervalType, func(x, y MemoryCell) (MemoryCell, error) {
+		return scaleInterval(x.AsInterval(), new(big.Rat).SetInt64(y.AsInt64()))
+	}},
+	{slashSymbol, IntervalType, IntType, IntervalType, func(x, y MemoryCell) (MemoryCell, error) {
+		return divideInterval(x.AsInterval(), y.AsInt64())
+	}},
+	{slashSymbol, IntervalType, BigIntType, IntervalType, func(x, y MemoryCell) (MemoryCell, error) {
+		return divideInterval(x.AsInterval(), y.AsInt64())
+	}},
+}
+
+// addIntervals adds sign times b to a field by field
+func addIntervals(a, b Interval, sign int64) (MemoryCell, error) {
+	months, ok := addIntervalField(a.Months, sign*int64(b.Months))
+	if !ok {
+		return nil, fmt.Errorf("%w: interval", ErrNumericOutOfRange)
+	}
+
+	days, ok := addIntervalField(a.Days, sign*int64(b.Days))
+	if !ok {
+		return nil, fmt.Errorf("%w: interval", ErrNumericOutOfRange)
+	}
+
+	micros := new(big.Int).Mul(big.NewInt(sign), big.NewInt(b.Microseconds))
+	micros.Add(micros, big.NewInt(a.Microseconds))
+	if !micros.IsInt64() {
+		return nil, fmt.Errorf("%w: interval", ErrNumericOutOfRange)
+	}
+
+	return intervalToCell(Interval{months, days, micros.Int64()}), nil
+}
+
+// scaleInterval multiplies every field of iv by factor, fractions of a month carry
+// down into days of 30 days and fractions of a day into microseconds, so a month
+// and a half is 1 month 15 days
+func scaleInterval(iv Interval, factor *big.Rat) (MemoryCell, error) {
+	months := new(big.Rat).Mul(big.NewRat(int64(iv.Months), 1), factor)
+	wholeMonths := truncateRat(months)
+
+	days := new(big.Rat).Mul(big.NewRat(int64(iv.Days), 1), factor)
+	days.Add(days, months.Sub(months, new(big.Rat).SetInt(wholeMonths)).Mul(months, big.NewRat(daysPerMonth, 1)))
+	wholeDays := truncateRat(days)
+
+	micros := new(big.Rat).Mul(big.NewRat(iv.Microseconds, 1), factor)
+	micros.Add(micros, days.Sub(days, new(big.Rat).SetInt(wholeDays)).Mul(days, big.NewRat(microsecondsPerDay, 1)))
+	wholeMicros := roundRat(micros)
+
+	if !wholeMonths.IsInt64() || !wholeDays.IsInt64() || !wholeMicros.IsInt64() {
+		return nil, fmt.Errorf("%w: interval", ErrNumericOutOfRange)
+	}
+
+	m, okMonths := addIntervalField(0, wholeMonths.Int64())
+	d, okDays := addIntervalField(0, wholeDays.Int64())
+	if !okMonths || !okDays {
+		return nil, fmt.Errorf("%w: interval", ErrNumericOutOfRange)
+	}
+
+	return intervalToCell(Interval{m, d, wholeMicros.Int64()}), nil
+}
+
+// divideInterval divides iv by n the way scaleInterval multiplies it
+func divideInterval(iv Interval, n int64) (MemoryCell, error) {
+	if n == 0 {
+		return nil, ErrDivisionByZero
+	}
+
+	return scaleInterval(iv, big.NewRat(1, n))
+}
+
+// findTemporalOperation looks up what op does to operands of types a and b, swapped
+// reports that the operation takes them the other way around, which only addition
+// and multiplication allow
+func findTemporalOperation(op Symbol, a, b ColumnType) (*temporalOperation, bool, bool) {
+	// NULL plus a number is arithmetic, not a date
+	if !isTemporal(a) && !isTemporal(b) {
+		return nil, false, false
+	}
+
+	for _, swapped := range []bool{false, true} {
+		x, y := a, b
+		if swapped {
+			x, y = b, a
+		}
+
+		if swapped && op != plusSymbol && op != asteriskSymbol {
+			break
+		}
+
+		// a NULL operand takes whichever type makes the operation work
+		for i, to := range temporalOperations {
+			if to.op == op && fitsType(x, to.a) && fitsType(y, to.b) {
+				return &temporalOperations[i], swapped, true
+			}
+		}
+	}
+
+	return nil, false, false
+}
+
+func (to *temporalOperation) apply(x, y MemoryCell, swapped bool) (MemoryCell, error) {
+	if x.IsNull() || y.IsNull() {
+		return nil, nil
+	}
+
+	if swapped {
+		x, y = y, x
+	}
+
+	return to.evaluate(x, y)
+}
+
+// compileTemporalArithmetic compiles arithmetic where one of the operands is a date,
+// time, timestamp or interval
+func compileTemporalArithmetic(op Symbol, a, b *compiledExpression) (*compiledExpression, bool) {
+	to, swapped, ok := findTemporalOperation(op, a.typ, b.typ)
+	if !ok {
+		return nil, false
+	}
+
+	return &compiledExpression{
+		typ: to.typ,
+		evaluate: func(row []MemoryCell) (MemoryCell, error) {
+			l, err := a.evaluate(row)
+			if err != nil {
+				return nil, err
+			}
+
+			r, err := b.evaluate(row)
+			if err != nil {
+				return nil, err
+			}
+
+			return to.apply(l, r, swapped)
+		},
+	}, true
+}
+
+// truncateTime cuts t down to the start of the given unit
+func truncateTime(t time.Time, unit string) (time.Time, error) {
+	year, month, day := t.Date()
+
+	switch strings.ToLower(unit) {
+	case "microseconds":
+		return t, nil
+	case "milliseconds":
+		return t.Truncate(time.Millisecond), nil
+	case "second":
+		return t.Truncate(time.Second), nil
+	case "minute":
+		return t.Truncate(time.Minute), nil
+	case "hour":
+		return t.Truncate(time.Hour), nil
+	case "day":
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), nil
+	case "week":
+		// weeks start on monday
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, time.UTC), nil
+	case "month":
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC), nil
+	case "quarter":
+		return time.Date(year, month-(month-1)%3, 1, 0, 0, 0, 0, time.UTC), nil
+	case "year":
+		return time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC), nil
+	case "decade":
+		return time.Date(year-year%10, 1, 1, 0, 0, 0, 0, time.UTC), nil
+	case "century":
+		return time.Date(year-(year-1)%100, 1, 1, 0, 0, 0, 0, time.UTC), nil
+	}
+
+	return t, fmt.Errorf("%w: unknown unit %s", ErrInvalidExpression, unit)
+}
+
+// extractField reads one field of a temporal cell as a number
+func extractField(field string, cell MemoryCell, typ ColumnType) (*big.Rat, error) {
+	field = strings.ToLower(field)
+
+	if typ == IntervalType {
+		iv := cell.AsInterval()
+		seconds := big.NewRat(iv.Microseconds%intervalUnits["minute"], intervalUnits["second"])
+
+		switch field {
+		case "year":
+			return big.NewRat(int64(iv.Months/12), 1), nil
+		case "month":
+			return big.NewRat(int64(iv.Months%12), 1), nil
+		case "day":
+			return big.NewRat(int64(iv.Days), 1), nil
+		case "hour":
+			return big.NewRat(iv.Microseconds/intervalUnits["hour"], 1), nil
+		case "minute":
+			return big.NewRat(iv.Microseconds%intervalUnits["hour"]/intervalUnits["minute"], 1), nil
+		case "second":
+			return seconds, nil
+		case "epoch":
+			// a year is 365.25 days here, a month 30 days
+			months := int64(iv.Months)
+			days := (months/12)*36525*microsecondsPerDay/100 + (months%12)*daysPerMonth*microsecondsPerDay + int64(iv.Days)*microsecondsPerDay
+			return big.NewRat(days+iv.Microseconds, intervalUnits["second"]), nil
+		}
+
+		return nil, fmt.Errorf("%w: unknown interval field %s", ErrInvalidExpression, field)
+	}
+
+	t := cell.AsTime()
+	micros := int64(t.Nanosecond()) / 1000
+
+	// a time of day has no date fields
+	if typ == TimeType && field != "hour" && field != "minute" && field != "second" && field != "milliseconds" && field != "microseconds" && field != "epoch" {
+		return nil, fmt.Errorf("%w: time has no field %s", ErrInvalidExpression, field)
+	}
+
+	switch field {
+	case "year":
+		return big.NewRat(int64(t.Year()), 1), nil
+	case "quarter":
+		return big.NewRat(int64(t.Month()-1)/3+1, 1), nil
+	case "month":
+		return big.NewRat(int64(t.Month()), 1), nil
+	case "week":
+		_, week := t.ISOWeek()
+		return big.NewRat(int64(week), 1), nil
+	case "day":
+		return big.NewRat(int64(t.Day()), 1), nil
+	case "dow":
+		return big.NewRat(int64(t.Weekday()), 1), nil
+	case "isodow":
+		return big.NewRat(int64(t.Weekday()+6)%7+1, 1), nil
+	case "doy":
+		return big.NewRat(int64(t.YearDay()), 1), nil
+	case "hour":
+		return big.NewRat(int64(t.Hour()), 1), nil
+	case "minute":
+		return big.NewRat(int64(t.Minute()), 1), nil
+	case "second":
+		return big.NewRat(int64(t.Second())*intervalUnits["second"]+micros, intervalUnits["second"]), nil
+	case "milliseconds":
+		return big.NewRat(int64(t.Second())*intervalUnits["second"]+micros, intervalUnits["millisecond"]), nil
+	case "microseconds":
+		return big.NewRat(int64(t.Second())*intervalUnits["second"]+micros, 1), nil
+	case "epoch":
+		return big.NewRat(t.UnixMicro(), intervalUnits["second"]), nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown field %s", ErrInvalidExpression, field)
+}
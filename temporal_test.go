@@ -0,0 +1,116 @@
+package memsql
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNullArithmeticIsNotTemporal(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table n (a int, b int);")
+	mustExecute(t, mb, "insert into n values (2, null + 1), (3, 1 - null);")
+
+	res := mustExecute(t, mb, "select null + 1, date '2026-01-01' + null from n;")
+	if res.Columns[0].Type != IntType || res.Columns[1].Type != DateType {
+		t.Errorf("got types %s and %s, want int and date", res.Columns[0].Type, res.Columns[1].Type)
+	}
+
+	expectRows(t, mb, "select a, b from n;", [][]string{{"2", "NULL"}, {"3", "NULL"}})
+}
+
+func TestCompareTemporalWithString(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table e (d date, ts timestamp);")
+	mustExecute(t, mb, "insert into e values ('2026-01-15', '2026-01-15 10:00'), ('2026-03-01', '2026-03-01 00:00');")
+
+	expectRows(t, mb, "select d from e where d > '2026-02-01';", [][]string{{"2026-03-01"}})
+	expectRows(t, mb, "select d from e where '2026-02-01' >= d;", [][]string{{"2026-01-15"}})
+	expectRows(t, mb, "select d from e where d between '2026-01-01' and '2026-02-01';", [][]string{{"2026-01-15"}})
+	expectRows(t, mb, "select d from e where d in ('2026-03-01', '2026-04-01');", [][]string{{"2026-03-01"}})
+	expectRows(t, mb, "select ts from e where ts < '2026-02-01';", [][]string{{"2026-01-15 10:00:00"}})
+}
+
+func TestInvalidTemporalText(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table e (d date, i interval);")
+
+	for _, source := range []string{
+		"select date 'bogus';",
+		"select cast('bogus' as date);",
+		"select interval '1 fortnight';",
+		"insert into e values ('2026-13-01', null);",
+		"insert into e values (null, 'soon');",
+	} {
+		if _, err := execute(mb, source); !errors.Is(err, ErrInvalidTextRepresentation) {
+			t.Errorf("%s: got %v, want %v", source, err, ErrInvalidTextRepresentation)
+		}
+	}
+}
+
+func TestIntervalOutOfRange(t *testing.T) {
+	mb := NewMemoryBackend()
+
+	for _, source := range []string{
+		"select interval '3000000000 days';",
+		"select interval '200000000 years';",
+		"select interval '2000000000 days 2000000000 days';",
+		"select interval '-3000000000 months';",
+		"select interval '100000000000000000000 seconds';",
+		"select interval '99999999999999999999 days';",
+	} {
+		if _, err := execute(mb, source); !errors.Is(err, ErrNumericOutOfRange) {
+			t.Errorf("%s: got %v, want %v", source, err, ErrNumericOutOfRange)
+		}
+	}
+
+	expectRows(t, mb, "select interval '2147483647 days', interval '-2147483648 months';", [][]string{{"2147483647 days", "-178956970 years -8 months"}})
+}
+
+func TestIntervalScaling(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table e (i interval, n int, b bigint);")
+	mustExecute(t, mb, "insert into e values ('1 month 1 day 01:00', 3, 2), ('2 hours', -2, null);")
+
+	expectRows(t, mb, "select i * n, n * i, i * b, i / n from e;", [][]string{
+		{"3 months 3 days 03:00:00", "3 months 3 days 03:00:00", "2 months 2 days 02:00:00", "10 days 08:20:00"},
+		{"-04:00:00", "-04:00:00", "NULL", "-01:00:00"},
+	})
+	expectRows(t, mb, "select interval '1 month' / 2, interval '3 days' / 2, interval '1 year' * 0;", [][]string{{"15 days", "1 day 12:00:00", "00:00:00"}})
+
+	res := mustExecute(t, mb, "select interval '1 day' * null, 2 * interval '1 day';")
+	expectColumns(t, res, []string{"?column?", "?column?"}, []ColumnType{IntervalType, IntervalType})
+
+	expectError(t, mb, "select interval '1 day' / 0;", ErrDivisionByZero)
+	expectError(t, mb, "select interval '1000000 days' * 1000000;", ErrNumericOutOfRange)
+	expectError(t, mb, "select 2 / interval '1 day';", ErrTypeMismatch)
+	expectError(t, mb, "select interval '1 day' * interval '1 day';", ErrTypeMismatch)
+	expectError(t, mb, "select interval '1 day' % 2;", ErrTypeMismatch)
+	expectError(t, mb, "select interval '1 day' * 'x';", ErrTypeMismatch)
+	expectError(t, mb, "select interval '2000000000 days' + interval '2000000000 days';", ErrNumericOutOfRange)
+}
+
+func TestTemporalFunctions(t *testing.T) {
+	mb := NewMemoryBackend()
+	mustExecute(t, mb, "create table e (d date, ts timestamp, t time);")
+	mustExecute(t, mb, "insert into e values ('2026-03-15', '2026-03-15 13:45:30.25', '08:30'), (null, null, null);")
+
+	expectRows(t, mb, "select d + 1, d - 1, d - date '2026-03-01', ts + interval '1 day 2 hours', t + interval '30 minutes' from e;", [][]string{
+		{"2026-03-16", "2026-03-14", "14", "2026-03-16 15:45:30.25", "09:00:00"},
+		{"NULL", "NULL", "NULL", "NULL", "NULL"},
+	})
+	expectRows(t, mb, "select extract(year from d), extract(month from ts), extract(dow from d), extract(second from ts) from e where d is not null;", [][]string{
+		{"2026", "3", "0", "30.25"},
+	})
+	expectRows(t, mb, "select date_trunc('month', ts), date_trunc('hour', ts), date_add(d, interval '1 month'), date_sub(ts, interval '1 year') from e where d is not null;", [][]string{
+		{"2026-03-01 00:00:00", "2026-03-15 13:00:00", "2026-04-15 00:00:00", "2025-03-15 13:45:30.25"},
+	})
+	expectRows(t, mb, "select count(*) from e where now() > ts;", [][]string{{"1"}})
+	expectRows(t, mb, "select date '2026-01-31' + interval '1 month', timestamp '2026-03-15 10:00' - timestamp '2026-03-14 08:30';", [][]string{
+		{"2026-02-28 00:00:00", "1 day 01:30:00"},
+	})
+
+	expectError(t, mb, "select extract(fortnight from d) from e;", ErrInvalidExpression)
+	expectError(t, mb, "select date_trunc('fortnight', ts) from e;", ErrInvalidExpression)
+	expectError(t, mb, "select d + ts from e;", ErrTypeMismatch)
+	expectError(t, mb, "select date_add(ts, ts) from e;", ErrTypeMismatch)
+}